@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.eduardoandres.dev/gitgen"
+)
+
+const checkIgnoreHelp = `Check ignored paths:
+	Tell which paths would be ignored by a set of gitignore
+	templates or files, and print the rule that matched them
+	in the same format as git check-ignore -v:
+		source:line:pattern	path
+	Paths ending with / or existing directories are
+	treated as directories
+	Examples:
+		gitgen check-ignore C -- build/out.o main.c
+		gitgen check-ignore Node .gitignore -- node_modules/ dist/app.js`
+
+// The result for a path, in the JSON format. The rule is
+// only given when one matched
+type checkResult struct {
+	Path    string `json:"path"`
+	Matched bool   `json:"matched"`
+	Ignored bool   `json:"ignored"`
+	Source  string `json:"source,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// Run the check-ignore sub command. The args start
+// after the sub command itself
+func checkIgnore(program string, args []string, format string, out testableWriter) error {
+	// Split templates and paths
+	sep := -1
+
+	for i, arg := range args {
+		if arg == "--" {
+			sep = i
+			break
+		}
+	}
+
+	if sep <= 0 || sep == len(args)-1 {
+		return newError(codeUsage, "Usage: %v check-ignore [template|file...] -- [path...]", program)
+	}
+
+	m := gitgen.NewMatcher()
+
+	for _, source := range args[:sep] {
+		err := addIgnoreSource(m, source)
+
+		switch {
+		case errors.Is(err, gitgen.ErrTemplateNotFound):
+			return notFoundError(err, "'%v' is not a gitignore template or file", source)
+
+		case err != nil:
+			return fileError(err)
+		}
+	}
+
+	// Every path is in the JSON, matched or not
+	results := make([]checkResult, 0, len(args)-sep-1)
+
+	for _, path := range args[sep+1:] {
+		match, ok := m.Match(path, isDirPath(path))
+
+		if format == formatJSON {
+			r := checkResult{Path: path, Matched: ok}
+
+			if ok {
+				r.Ignored = match.Ignored()
+				r.Source, r.Line, r.Pattern = match.Source, match.Line, match.Text
+			}
+
+			results = append(results, r)
+			continue
+		}
+
+		if ok {
+			fmt.Fprintf(out, "%v:%d:%v\t%v\n",
+				match.Source, match.Line, match.Text, path)
+		}
+	}
+
+	if format == formatJSON {
+		return writeJSON(out, results)
+	}
+
+	return nil
+}
+
+// Add an embeded template or, if there is no
+// such template, a gitignore file from disk
+func addIgnoreSource(m *gitgen.Matcher, source string) error {
+	err := m.AddTemplate(source)
+
+	if !errors.Is(err, gitgen.ErrTemplateNotFound) {
+		return err
+	}
+
+	f, openErr := os.Open(source)
+
+	switch {
+	case os.IsNotExist(openErr):
+		// Report the missing template, with its suggestions
+		return err
+
+	case openErr != nil:
+		return openErr
+	}
+
+	defer f.Close()
+
+	parsed, err := gitgen.ParseIgnore(f)
+
+	if err != nil {
+		return err
+	}
+
+	m.Add(source, parsed)
+
+	return nil
+}
+
+// Paths ending with a slash or pointing to an
+// existing directory are directories
+func isDirPath(path string) bool {
+	if strings.HasSuffix(path, "/") {
+		return true
+	}
+
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_subcommandCheckIgnore(t *testing.T) {
+	// A gitignore file on disk
+	dir := t.TempDir()
+	custom := filepath.Join(dir, "custom.gitignore")
+
+	os.WriteFile(custom, []byte("# Ours\n!keep.o\n"), 0644)
+
+	tests := []testCase{
+		{
+			"Check C template",
+			[]string{"gg", "check-ignore", "C", "--", "build/out.o", "main.c"},
+			exitOK, "", "C:5:*.o\tbuild/out.o\n",
+		},
+
+		{
+			"Directories",
+			[]string{"gg", "ci", "Node", "--", "node_modules/", "node_modules"},
+			exitOK, "", "Node:41:node_modules/\tnode_modules/\n",
+		},
+
+		{
+			"Template and a custom file",
+			[]string{"gg", "ci", "C", custom, "--", "keep.o"},
+			exitOK, "", custom + ":2:!keep.o\tkeep.o\n",
+		},
+
+		{
+			"Missing separator",
+			[]string{"gg", "ci", "C", "main.o"}, exitUsage,
+			"Usage: gg check-ignore [template|file...] -- [path...]", "",
+		},
+
+		{
+			"No paths",
+			[]string{"gg", "ci", "C", "--"}, exitUsage,
+			"Usage: gg check-ignore [template|file...] -- [path...]", "",
+		},
+
+		{
+			"Bad template",
+			[]string{"gg", "ci", "Pyhton", "--", "a.pyc"}, exitNotFound,
+			"'Pyhton' is not a gitignore template or file. Did you mean Python?", "",
+		},
+
+		{
+			"Unreadable file",
+			[]string{"gg", "ci", "C", dir, "--", "a.o"}, exitIO,
+			"Error: read " + dir + ": is a directory", "",
+		},
+
+		{
+			"Help",
+			[]string{"gg", "help", "check-ignore"},
+			exitOK, "", checkIgnoreHelp,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.eduardoandres.dev/gitgen"
+)
+
+// Where the answers of the wizard come from. Tests replace it
+var stdin io.Reader = os.Stdin
+
+// A question of the wizard and the criteria it sets
+type question struct {
+	text string
+	req  *gitgen.Requirement
+}
+
+// Ask questions about the project, print the licenses that fit
+// and optionally write the chosen one with the given flags
+func chooseLicense(l licenseFlags, o outputOptions, format string, out testableWriter) error {
+	var c gitgen.Criteria
+
+	questions := []question{
+		{"Must changes to your code be published as source (copyleft)?", &c.Copyleft},
+		{"Do you want an express grant of patent rights?", &c.PatentGrant},
+		{"Must the source be shared when the software is used over a network?", &c.NetworkDisclosure},
+		{"Must changes keep the same license?", &c.SameLicense},
+	}
+
+	in := bufio.NewScanner(stdin)
+
+	out.WriteString("Answer y(es), n(o) or press enter if you do not mind\n")
+
+	for _, q := range questions {
+		req, err := ask(q.text, in, out)
+
+		if err != nil {
+			return err
+		}
+
+		*q.req = req
+	}
+
+	recs := gitgen.RecommendLicenses(c)
+
+	if len(recs) == 0 {
+		return newError(codeNoResult, "Error: No license fits those answers")
+	}
+
+	out.WriteString("\nThese licenses fit your project:\n")
+
+	for _, rec := range recs {
+		// The SPDX id works as a key too
+		id := rec.License.SPDX
+
+		if id == "" {
+			id = rec.License.Key
+		}
+
+		fmt.Fprintf(out, "\t%v\t%v", id, rec.License.Name)
+
+		if len(rec.Reasons) != 0 {
+			fmt.Fprintf(out, ": %v", strings.Join(rec.Reasons, ", "))
+		}
+
+		out.WriteString("\n")
+	}
+
+	out.WriteString("\nType a license to write it, or press enter to finish: ")
+
+	if !in.Scan() || strings.TrimSpace(in.Text()) == "" {
+		out.WriteString("\n")
+		return nil
+	}
+
+	key := strings.TrimSpace(in.Text())
+
+	// Without -o the license goes to the LICENSE of the repo,
+	// as the output is taken by the wizard
+	if o.path == "" {
+		o.repo = true
+	}
+
+	return licenseError(key, l.emit(key, o, format, out))
+}
+
+// Ask a yes or no question until the answer is valid
+func ask(text string, in *bufio.Scanner, out testableWriter) (gitgen.Requirement, error) {
+	for {
+		fmt.Fprintf(out, "%v [y/n/enter] ", text)
+
+		if !in.Scan() {
+			return gitgen.Any, newError(codeIO, "Error: The wizard was closed before it finished")
+		}
+
+		switch strings.ToLower(strings.TrimSpace(in.Text())) {
+		case "":
+			return gitgen.Any, nil
+		case "y", "yes":
+			return gitgen.Required, nil
+		case "n", "no":
+			return gitgen.Forbidden, nil
+		}
+
+		out.WriteString("Please answer y, n or press enter\n")
+	}
+}
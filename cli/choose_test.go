@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Run the wizard with some answers
+func runChoose(t *testing.T, answers string, args ...string) testCase {
+	stdin = strings.NewReader(answers)
+
+	t.Cleanup(func() { stdin = os.Stdin })
+
+	return testCase{args: append([]string{"gg", "lic", "choose"}, args...)}
+}
+
+const chooseQuestions = `Answer y(es), n(o) or press enter if you do not mind
+Must changes to your code be published as source (copyleft)? [y/n/enter] ` +
+	`Do you want an express grant of patent rights? [y/n/enter] ` +
+	`Must the source be shared when the software is used over a network? [y/n/enter] ` +
+	`Must changes keep the same license? [y/n/enter] `
+
+const chooseApache = chooseQuestions + `
+These licenses fit your project:
+	Apache-2.0	Apache License 2.0: permissive: changes can be kept closed, grants patent rights
+
+Type a license to write it, or press enter to finish: `
+
+func Test_subcommandChoose(t *testing.T) {
+	tt := runChoose(t, "n\ny\n\n\n\n")
+	tt.name = "Choose a permissive license with patents"
+	tt.wantPrinted = chooseApache + "\n"
+	tt.runTest(t)
+
+	tt = runChoose(t, "maybe\nn\ny\n\n\n\n")
+	tt.name = "Ask again after a bad answer"
+	tt.wantPrinted = strings.Replace(chooseApache,
+		"(copyleft)? [y/n/enter] ",
+		"(copyleft)? [y/n/enter] Please answer y, n or press enter\n"+
+			"Must changes to your code be published as source (copyleft)? [y/n/enter] ", 1) + "\n"
+	tt.runTest(t)
+
+	tt = runChoose(t, "n\n")
+	tt.name = "Input closed"
+	tt.wantCode = exitIO
+	tt.wantMsg = "Error: The wizard was closed before it finished"
+	tt.wantPrinted = "Answer y(es), n(o) or press enter if you do not mind\n" +
+		"Must changes to your code be published as source (copyleft)? [y/n/enter] " +
+		"Do you want an express grant of patent rights? [y/n/enter] "
+	tt.runTest(t)
+
+	tt = runChoose(t, "n\n\n\ny\n")
+	tt.name = "Nothing fits"
+	tt.wantCode = exitFailure
+	tt.wantMsg = "Error: No license fits those answers"
+	tt.wantPrinted = chooseQuestions
+	tt.runTest(t)
+}
+
+func Test_subcommandChooseWrite(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "LICENSE")
+
+	tt := runChoose(t, "n\ny\n\n\nApache-2.0\n", "-o", name)
+	tt.name = "Write the chosen license"
+	tt.wantPrinted = chooseApache + "Wrote 11346 bytes to " + name + "\n"
+	tt.runTest(t)
+
+	data, _ := os.ReadFile(name)
+
+	if !strings.Contains(string(data), "Copyright 2021 Eduardo Castillo") {
+		t.Error("The license was not written with the defaults")
+	}
+}
@@ -2,9 +2,11 @@ package main
 
 import (
 	_ "embed"
-	"fmt"
+	"errors"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"go.eduardoandres.dev/gitgen"
 )
@@ -18,17 +20,14 @@ var ignoreHelpText string
 //go:embed licHelpText.txt
 var licHelpText string
 
-const lsHelp = `List template files:
-	Generate available .gitignore and license template files
-	Examples:
-		gitgen ls license
-		gitgen ls ignore`
-
 func main() {
-	// Pass the os arguments, the std out and the
-	// error out to the cli
-	cli(os.Args, os.Stdout, os.Stderr)
+	// The templates of the user go before the embeded ones
+	gitgen.AddTemplateDirs(gitgen.UserTemplateDirs()...)
 
+	// Pass the os arguments, the std out and the
+	// error out to the cli. Scripts need to know
+	// when and why it failed
+	os.Exit(cli(os.Args, os.Stdout, os.Stderr))
 }
 
 // An interface that accepts a normal writer (such as files)
@@ -39,118 +38,199 @@ type testableWriter interface {
 	io.Writer
 }
 
-// Make this testable
-func cli(args []string, out, errOut testableWriter) {
+// Make this testable. It returns the exit code: exitOK, or
+// the code of the error that was printed to errOut in the
+// chosen format
+func cli(args []string, out, errOut testableWriter) int {
+	format, args, err := globalFlags(args)
+
+	if err == nil {
+		err = run(args, format, out)
+	}
+
+	if err != nil {
+		// Print to the error, which is usally
+		// stderr but not in unit testing
+		return printError(err, format, errOut)
+	}
 
-	// Act uppon the sub command
+	return exitOK
+}
 
+// Act uppon the sub command
+func run(args []string, format string, out testableWriter) error {
 	tokens := len(args)
 
 	// Avoid panic
-
 	if tokens <= 1 {
-		/*fail = true
-		msg = fmt.Sprintf("Error: No sub command. Please type %v help for more information", args[0])
-
-		return*/
-
-		// Print to the error, which is usally
-		// stderr but not in unit testing
-		fmt.Fprintf(errOut,
+		return newError(codeUsage,
 			"Error: No sub command. Please type %v help for more information",
 			args[0])
-
-		return
 	}
 
 	switch args[1] {
 	case "help", "h":
 		if tokens == 2 {
 			out.WriteString(helpText)
-		} else {
-			printHelp(args[2], out, errOut)
+			return nil
 		}
 
+		return printHelp(args[2], out)
+
 	case "ignore", "gitignore", "i":
-		// Just print the required ignore
+		return ignore(args[0], args[2:], format, out)
 
-		// Bad usage
-		if tokens < 3 {
-			// Make error message with the name of the program
-			fmt.Fprintf(errOut, "Usage: %v [ignore|gitignore|i] [ignore template]", args[0])
+	case "license", "lic", "li", "l":
+		return license(args[0], args[2:], format, out)
 
-			return
-		}
+	case "check-ignore", "ci":
+		return checkIgnore(args[0], args[2:], format, out)
 
-		// Write to stdout (or test out) and check if the file
-		// could be retrieved
-		if _, err := gitgen.WriteIgnore(args[2], out); err != nil {
-			fmt.Fprintf(errOut,
-				"'%v' gitignore template does not exist", args[2])
+	case "detect":
+		return detect(args[2:], format, out)
 
-			return
-		}
+	case "update":
+		return update(args[0], args[2:], format, out)
 
-	case "license", "lic", "li", "l":
+	case "list", "ls":
+		return list(args[0], args[2:], format, out)
 
-		// Incomplete command
-		if tokens < 3 {
-			fmt.Fprintf(errOut, "Error: Incomplete command. Usage: %v [license|lic|l] [license name] (optional flags -y year -n name)", args[0])
-			return
-		}
+	case "header":
+		return header(args[0], args[2:], format, out)
+	}
 
-		// Write the license to the out (either test, stdout, etc)
-		// given the flags and the argument
+	// Unknown sub
+	return newError(codeUsage,
+		"Error: Unknown subcommand '%v'. Please type xd help for mor information", args[1])
+}
 
-		// Check if there are enough params for the year and name
-		if tokens >= 5 {
+// Run the ignore sub command. The args start
+// after the sub command itself
+func ignore(program string, args []string, format string, out testableWriter) error {
+	var o outputOptions
+	var auto, merge, managed, global, local bool
 
-			// Arguments for year and name present
+	fs := newFlagSet("ignore")
+	o.register(fs)
 
-			// If the license does not exist,
-			// then the written bytes will be 0
-			if n, err := gitgen.WriteLicWithParams(args[2],
-				args[4], args[3], out); n == 0 || err != nil {
+	boolFlag(fs, &auto, "", "auto", "Use the detected templates")
+	boolFlag(fs, &merge, "m", "merge", "Add only the missing rules")
+	boolFlag(fs, &managed, "", "managed", "Write managed blocks")
+	boolFlag(fs, &global, "", "global", "Write to the global excludes file")
+	boolFlag(fs, &local, "", "local", "Write to .git/info/exclude")
 
-				fmt.Fprintf(errOut, "Error: Unknown license '%v'", args[2])
-				return
-			}
+	keys, err := parseArgs(fs, args)
 
-		} else {
-			// Use only the license as is
-			if _, err := gitgen.WriteLicense(args[2], out); err != nil {
-				fmt.Fprintf(errOut, "Error: Unknown license '%v'", args[2])
-				return
-			}
-		}
+	if err != nil {
+		return newError(codeUsage, "Error: %v", err)
+	}
 
-	case "list", "ls":
-		// Bad usage
-		if tokens < 3 {
-			// Make error message with the name of the program
-			fmt.Fprintf(errOut, "Usage: %v [list|ls] [ignore|i|license|l]", args[0])
+	if merge && managed {
+		return newError(codeUsage, "Error: --merge and --managed can not be used together")
+	}
+
+	if global && local {
+		return newError(codeUsage, "Error: --global and --local can not be used together")
+	}
+
+	if (global || local) && (o.path != "" || o.repo) {
+		return newError(codeUsage, "Error: --global and --local can not be used with --output or --write")
+	}
+
+	// Bad usage
+	if len(keys) == 0 && !auto {
+		// Make error message with the name of the program
+		return newError(codeUsage,
+			"Usage: %v [ignore|gitignore|i] [--auto] [--merge|--managed] [--global|--local] [ignore template...]", program)
+	}
 
-			return
+	// Use the templates detected in the current directory
+	if auto {
+		if keys, err = autoIgnoreKeys(keys); err != nil {
+			return err
+		}
+	}
+
+	// The excludes files are shared by every template, so
+	// the templates are added to them
+	if global || local {
+		if o.path, err = excludesFile(local); err != nil {
+			return fileError(err)
 		}
 
-		switch args[2] {
-		case "ignore", "i":
-			listIgnore(out)
-		case "license", "lic", "l":
-			listLic(out)
-		default:
-			// Make error message with the name of the program
-			fmt.Fprintf(errOut, "Usage: %v [list|ls] [ignore|i|license|l]", args[0])
+		if err = os.MkdirAll(filepath.Dir(o.path), 0755); err != nil {
+			return fileError(err)
 		}
+
+		merge = !managed
+	}
+
+	switch {
+	case managed && (global || local):
+		// Update the blocks already there instead of repeating them
+		err = manageIgnore(o, keys, format, out)
+
+	case merge:
+		// Add only the missing rules to an existing file
+		err = mergeIgnore(o, keys, format, out)
+
 	default:
-		// Unknown sub
-		fmt.Fprintf(errOut,
-			"Error: Unknown subcommand '%v'. Please type xd help for mor information", args[1])
-		return
+		// Write to stdout (or test out, or a file) and check if the
+		// file could be retrieved. Many templates are combined in one
+		err = emit(o, ".gitignore", func(w io.Writer) (int, error) {
+			// Wrap the templates so gitgen update can refresh them
+			if managed {
+				return gitgen.WriteManagedIgnores(keys, w)
+			}
+
+			if len(keys) == 1 {
+				return gitgen.WriteIgnore(keys[0], w)
+			}
+
+			return gitgen.CombineIgnores(keys, w)
+		}, format, out)
+	}
+
+	var nf *gitgen.NotFoundError
+
+	switch {
+	case errors.As(err, &nf):
+		// Tell which of the templates failed
+		return notFoundError(err, "'%v' gitignore template does not exist", nf.Key)
+
+	case errors.Is(err, gitgen.ErrMalformedBlock):
+		return newError(codeInvalid, "Error: %v: %v", o.path, err)
+
+	case err != nil:
+		return fileError(err)
+	}
+
+	return nil
+}
+
+// Get the excludes file of the user, or the
+// .git/info/exclude of the repository if local
+func excludesFile(local bool) (string, error) {
+	if local {
+		return gitgen.LocalExcludesFile(".")
 	}
+
+	return gitgen.GlobalExcludesFile(gitgen.UserGitConfig("."))
+}
+
+// Make a "did you mean" hint from the suggestions of a
+// gitgen.NotFoundError. It is empty if there are none
+func didYouMean(err error) string {
+	var nf *gitgen.NotFoundError
+
+	if !errors.As(err, &nf) || len(nf.Suggestions) == 0 {
+		return ""
+	}
+
+	return ". Did you mean " + strings.Join(nf.Suggestions, ", ") + "?"
 }
 
-func printHelp(subCommand string, out, err testableWriter) {
+func printHelp(subCommand string, out testableWriter) error {
 
 	switch subCommand {
 	case "gitignore", "ignore", "i":
@@ -162,30 +242,19 @@ func printHelp(subCommand string, out, err testableWriter) {
 		out.WriteString(licHelpText)
 	case "list", "ls":
 		out.WriteString(lsHelp)
+	case "check-ignore", "ci":
+		out.WriteString(checkIgnoreHelp)
+	case "detect":
+		out.WriteString(detectHelp)
+	case "update":
+		out.WriteString(updateHelp)
+	case "header":
+		out.WriteString(headerHelp)
 
 	default:
 		// Unknown sub command
-
-		// Set returns accordingly
-
-		fmt.Fprintf(err, "Unknown subcommand: '%v'", subCommand)
+		return newError(codeUsage, "Unknown subcommand: '%v'", subCommand)
 	}
-}
 
-// Print list of ignores to the output (stdout)
-func listIgnore(out testableWriter) {
-	ignores := gitgen.ListIgnores()
-
-	for _, ignore := range ignores {
-		fmt.Fprintln(out, ignore)
-	}
-}
-
-// The same but with licenses
-func listLic(out testableWriter) {
-	lics := gitgen.ListLicenses()
-
-	for _, lic := range lics {
-		fmt.Fprintln(out, lic)
-	}
+	return nil
 }
@@ -11,7 +11,7 @@ import (
 type testCase struct {
 	name                 string
 	args                 []string
-	wantFail             bool
+	wantCode             int
 	wantMsg, wantPrinted string
 }
 
@@ -22,7 +22,7 @@ func (tt *testCase) runTest(t *testing.T) {
 	// Create a fake StdErr
 	tstErr := new(strings.Builder)
 
-	cli(tt.args, tstOut, tstErr)
+	gotCode := cli(tt.args, tstOut, tstErr)
 
 	// Check the output
 	printed := tstOut.String()
@@ -35,9 +35,14 @@ func (tt *testCase) runTest(t *testing.T) {
 
 	t.Run(tt.name, func(t *testing.T) {
 
-		// Check the result
-		if gotFail != tt.wantFail {
-			t.Errorf("cli() gotFail = %v, want %v", gotFail, tt.wantFail)
+		// Check the exit code
+		if gotCode != tt.wantCode {
+			t.Errorf("cli() gotCode = %v, want %v", gotCode, tt.wantCode)
+		}
+
+		// Something is printed to stderr if and only if it failed
+		if gotFail != (tt.wantCode != exitOK) {
+			t.Errorf("cli() gotFail = %v, but the code is %v", gotFail, gotCode)
 		}
 
 		// Check the error message
@@ -60,14 +65,14 @@ func Test_cli(t *testing.T) {
 		{
 			"Bad input: No sub command",
 			[]string{"xd"},
-			true, "Error: No sub command. Please type xd help for more information",
+			exitUsage, "Error: No sub command. Please type xd help for more information",
 			"",
 		},
 
 		{
 			"Bad input: Unknown sub command",
 			[]string{"xd", "WakandaForever"},
-			true, "Error: Unknown subcommand 'WakandaForever'. Please type xd help for mor information",
+			exitUsage, "Error: Unknown subcommand 'WakandaForever'. Please type xd help for mor information",
 			"",
 		},
 	}
@@ -85,13 +90,13 @@ func Test_subcommandHelp(t *testing.T) {
 		{
 			"Normal help text",
 			[]string{"gg", "help"},
-			false, "", helpText,
+			exitOK, "", helpText,
 		},
 
 		{
 			"Normal help text using h shorcut",
 			[]string{"gg.exe", "h"},
-			false, "", helpText,
+			exitOK, "", helpText,
 		},
 
 		// Ignores help text
@@ -100,38 +105,38 @@ func Test_subcommandHelp(t *testing.T) {
 		{
 			"Help for the ignores: help gitignore",
 			[]string{"gg.exe", "help", "gitignore"},
-			false, "", ignoreHelpText,
+			exitOK, "", ignoreHelpText,
 		},
 
 		{
 			"Help for the ignores: help ignore",
 			[]string{"gg.exe", "help", "ignore"},
-			false, "", ignoreHelpText,
+			exitOK, "", ignoreHelpText,
 		},
 
 		{
 			"Help for the ignores: help i",
 			[]string{"gg.exe", "help", "i"},
-			false, "", ignoreHelpText,
+			exitOK, "", ignoreHelpText,
 		},
 
 		// h
 		{
 			"Help for the ignores: h gitignore",
 			[]string{"gg.exe", "h", "gitignore"},
-			false, "", ignoreHelpText,
+			exitOK, "", ignoreHelpText,
 		},
 
 		{
 			"Help for the ignores: h ignore",
 			[]string{"gg.exe", "h", "ignore"},
-			false, "", ignoreHelpText,
+			exitOK, "", ignoreHelpText,
 		},
 
 		{
 			"Help for the ignores h i",
 			[]string{"gg.exe", "h", "i"},
-			false, "", ignoreHelpText,
+			exitOK, "", ignoreHelpText,
 		},
 
 		// License help text
@@ -140,52 +145,52 @@ func Test_subcommandHelp(t *testing.T) {
 		{
 			"Help for the licenses: help license",
 			[]string{"gg.exe", "help", "license"},
-			false, "", licHelpText,
+			exitOK, "", licHelpText,
 		},
 
 		{
 			"Help for the licenses: help lic",
 			[]string{"gg.exe", "help", "lic"},
-			false, "", licHelpText,
+			exitOK, "", licHelpText,
 		},
 
 		{
 			"Help for the licenses: help l",
 			[]string{"gg.exe", "help", "l"},
-			false, "", licHelpText,
+			exitOK, "", licHelpText,
 		},
 
 		// h
 		{
 			"Help for the licenses: h license",
 			[]string{"gg.exe", "h", "license"},
-			false, "", licHelpText,
+			exitOK, "", licHelpText,
 		},
 
 		{
 			"Help for the licenses: h lic",
 			[]string{"gg.exe", "h", "lic"},
-			false, "", licHelpText,
+			exitOK, "", licHelpText,
 		},
 
 		{
 			"Help for the licenses h l",
 			[]string{"gg.exe", "h", "l"},
-			false, "", licHelpText,
+			exitOK, "", licHelpText,
 		},
 
 		// Unknown help
 		{
 			"Help for unknown sub: h xd",
 			[]string{"gg.exe", "h", "xd"},
-			true, "Unknown subcommand: 'xd'", "",
+			exitUsage, "Unknown subcommand: 'xd'", "",
 		},
 
 		// List help
 		{
 			"Help for the list sub help list",
 			[]string{"gg.exe", "help", "ls"},
-			false, "", lsHelp,
+			exitOK, "", lsHelp,
 		},
 	}
 
@@ -202,27 +207,53 @@ func Test_subcommandIgnore(t *testing.T) {
 		{
 			"Ignore Yeoman",
 			[]string{"gg", "ignore", "Yeoman"},
-			false, "", fullYeomanIgnore,
+			exitOK, "", fullYeomanIgnore,
 		},
 
 		// Tests for ignore
 		{
 			"Ignore Yeoman shorcut",
 			[]string{"gg", "i", "Yeoman"},
-			false, "", fullYeomanIgnore,
+			exitOK, "", fullYeomanIgnore,
+		},
+
+		{
+			"Ignore yeoman case insensitive",
+			[]string{"gg", "i", "yeoman"},
+			exitOK, "", fullYeomanIgnore,
 		},
 
 		{
 			"Ignore BAD TEMPLATE NAME",
-			[]string{"gg", "i", "WakandaForever"}, true,
+			[]string{"gg", "i", "WakandaForever"}, exitNotFound,
+			"'WakandaForever' gitignore template does not exist",
+			"",
+		},
+
+		{
+			"Ignore misspelled template",
+			[]string{"gg", "i", "Pyhton"}, exitNotFound,
+			"'Pyhton' gitignore template does not exist. Did you mean Python?",
+			"",
+		},
+
+		{
+			"Combine Ada and CUDA",
+			[]string{"gg", "i", "Ada", "cuda"},
+			exitOK, "", fullAdaCUDA,
+		},
+
+		{
+			"Combine with a BAD TEMPLATE NAME",
+			[]string{"gg", "i", "Ada", "WakandaForever"}, exitNotFound,
 			"'WakandaForever' gitignore template does not exist",
 			"",
 		},
 
 		{
 			"Ignore INCOMPLETE",
-			[]string{"gg", "ignore"}, true,
-			"Usage: gg [ignore|gitignore|i] [ignore template]",
+			[]string{"gg", "ignore"}, exitUsage,
+			"Usage: gg [ignore|gitignore|i] [--auto] [--merge|--managed] [--global|--local] [ignore template...]",
 			"",
 		},
 	}
@@ -237,7 +268,7 @@ func Test_subcommandLicense(t *testing.T) {
 		// Incomplete command
 		{
 			"Incomplete license sub command",
-			[]string{"xd", "lic"}, true,
+			[]string{"xd", "lic"}, exitUsage,
 			"Error: Incomplete command. Usage: xd [license|lic|l] [license name] (optional flags -y year -n name)", "",
 		},
 
@@ -245,14 +276,20 @@ func Test_subcommandLicense(t *testing.T) {
 
 		{
 			"Unknown License",
-			[]string{"xd", "lic", "lol"}, true,
+			[]string{"xd", "lic", "lol"}, exitNotFound,
 			"Error: Unknown license 'lol'", "",
 		},
 
+		{
+			"Misspelled License",
+			[]string{"xd", "lic", "gpl-4.0"}, exitNotFound,
+			"Error: Unknown license 'gpl-4.0'. Did you mean gpl-2.0, gpl-3.0?", "",
+		},
+
 		// Test for license without parameters
 		{
 			"Unlicense Without parameters",
-			[]string{"xd", "lic", "unlicense"}, false,
+			[]string{"xd", "lic", "unlicense"}, exitOK,
 			"", fullUnlicense,
 		},
 
@@ -261,7 +298,7 @@ func Test_subcommandLicense(t *testing.T) {
 			"MIT With params",
 			[]string{"xd", "lic", "mit",
 				"2021", "Eduardo Castillo"},
-			false,
+			exitOK,
 			"", fullMITWithParams,
 		},
 
@@ -270,7 +307,7 @@ func Test_subcommandLicense(t *testing.T) {
 			"IMAGINARY License With params",
 			[]string{"xd", "lic", "lol",
 				"2021", "Eduardo Castillo"},
-			true,
+			exitNotFound,
 			"Error: Unknown license 'lol'", "",
 		},
 	}
@@ -285,6 +322,9 @@ func Test_subcommandLicense(t *testing.T) {
 //go:embed testfiles/Yeoman.gitignore
 var fullYeomanIgnore string
 
+//go:embed testfiles/AdaCUDA.gitignore
+var fullAdaCUDA string
+
 // Test licenses
 
 //go:embed testfiles/unlicense.txt
@@ -309,9 +349,11 @@ func Test_listIgnore(t *testing.T) {
 	tstOut := new(strings.Builder)
 
 	// Make the fake output
-	listIgnore(tstOut)
+	if err := list("xd", []string{"ignore"}, formatText, tstOut); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
 
-	testLines(tstOut, 127, t)
+	testLines(tstOut, 134, t)
 }
 
 func Test_listLic(t *testing.T) {
@@ -319,7 +361,9 @@ func Test_listLic(t *testing.T) {
 	tstOut := new(strings.Builder)
 
 	// Make the fake console output
-	listLic(tstOut)
+	if err := list("xd", []string{"license"}, formatText, tstOut); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
 
 	// Check results
 	testLines(tstOut, 13, t)
@@ -329,14 +373,14 @@ func Test_subcommandList(t *testing.T) {
 	cases := []testCase{
 		{
 			"Incomplete list sub command",
-			[]string{"xd", "list"}, true,
-			"Usage: xd [list|ls] [ignore|i|license|l]", "",
+			[]string{"xd", "list"}, exitUsage,
+			"Usage: xd [list|ls] [--long|--json] [ignore|i|license|l] [filter]", "",
 		},
 
 		{
 			"Bad thing to list",
-			[]string{"xd", "list", "wakandaforever"}, true,
-			"Usage: xd [list|ls] [ignore|i|license|l]", "",
+			[]string{"xd", "list", "wakandaforever"}, exitUsage,
+			"Usage: xd [list|ls] [--long|--json] [ignore|i|license|l] [filter]", "",
 		},
 	}
 
@@ -351,7 +395,7 @@ func Test_subcommandList(t *testing.T) {
 
 		cli([]string{"gitgen", "ls", "ignore"}, tstOut, nil)
 
-		testLines(tstOut, 127, t)
+		testLines(tstOut, 134, t)
 	})
 
 	t.Run("Test ls license prints something", func(t *testing.T) {
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+
+	"go.eduardoandres.dev/gitgen"
+)
+
+const detectHelp = `Detect templates:
+	Scan a project directory (the current one by default) and
+	print the gitignore templates that apply to it, with the
+	file that revealed each one
+	Use gitgen i --auto to generate the .gitignore directly
+	Examples:
+		gitgen detect
+		gitgen detect ./my-project
+		gitgen i --auto > .gitignore`
+
+// Run the detect sub command. The args start
+// after the sub command itself
+func detect(args []string, format string, out testableWriter) error {
+	dir := "."
+
+	if len(args) != 0 {
+		dir = args[0]
+	}
+
+	detections, err := detectIn(dir)
+
+	if err != nil {
+		return err
+	}
+
+	if format == formatJSON {
+		return writeJSON(out, detections)
+	}
+
+	for _, d := range detections {
+		fmt.Fprintf(out, "%v\t%v\n", d.Template, d.Marker)
+	}
+
+	return nil
+}
+
+// Detect the templates of a directory. It is an
+// error if it fails or nothing is found
+func detectIn(dir string) ([]gitgen.Detection, error) {
+	detections, err := gitgen.DetectIgnores(dir)
+
+	if err != nil {
+		return nil, newError(codeIO, "Error: Could not scan '%v': %v", dir, err)
+	}
+
+	if len(detections) == 0 {
+		return nil, newError(codeNoResult, "Error: No templates detected in '%v'", dir)
+	}
+
+	return detections, nil
+}
+
+// Get the keys for gitgen i --auto: the detected
+// templates plus any other requested template
+func autoIgnoreKeys(extra []string) ([]string, error) {
+	detections, err := detectIn(".")
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(detections)+len(extra))
+
+	for _, d := range detections {
+		keys = append(keys, d.Template)
+	}
+
+	return append(keys, extra...), nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Create a project with a go.mod and a package.json
+func makeProject(t *testing.T) string {
+	dir := t.TempDir()
+
+	os.WriteFile(filepath.Join(dir, "go.mod"), nil, 0644)
+	os.Mkdir(filepath.Join(dir, "web"), 0755)
+	os.WriteFile(filepath.Join(dir, "web", "package.json"), nil, 0644)
+
+	return dir
+}
+
+func Test_subcommandDetect(t *testing.T) {
+	dir := makeProject(t)
+	empty := t.TempDir()
+
+	tests := []testCase{
+		{
+			"Detect a project",
+			[]string{"gg", "detect", dir},
+			exitOK, "", "Go\tgo.mod\nNode\tweb/package.json\n",
+		},
+
+		{
+			"Nothing detected",
+			[]string{"gg", "detect", empty}, exitFailure,
+			"Error: No templates detected in '" + empty + "'", "",
+		},
+
+		{
+			"Help",
+			[]string{"gg", "help", "detect"},
+			exitOK, "", detectHelp,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+}
+
+func Test_ignoreAuto(t *testing.T) {
+	dir := makeProject(t)
+
+	// Run the command inside the project
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+
+	os.Chdir(dir)
+
+	tstOut := new(strings.Builder)
+	tstErr := new(strings.Builder)
+
+	cli([]string{"gg", "i", "--auto", "Python"}, tstOut, tstErr)
+
+	if tstErr.Len() != 0 {
+		t.Fatal("Unexpected error: ", tstErr.String())
+	}
+
+	want := "# Generated by gitgen from: Go, Node, Python\n"
+
+	if got := tstOut.String(); !strings.HasPrefix(got, want) {
+		t.Errorf("Expected the output to start with '%v'", want)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"go.eduardoandres.dev/gitgen"
+)
+
+// The codes of the errors, given in the JSON output so scripts
+// can tell them apart. They are part of the output format, so
+// they must not change
+const (
+	// The command was not called properly
+	codeUsage = "usage"
+
+	// A template or license does not exist
+	codeNotFound = "not_found"
+
+	// The file to write already exists
+	codeExists = "exists"
+
+	// A file could not be read or written
+	codeIO = "io"
+
+	// A file has content gitgen does not understand
+	codeInvalid = "invalid"
+
+	// The command ran but found nothing, like detect
+	// in a folder without known files
+	codeNoResult = "no_result"
+
+	// A license has placeholders without a value
+	codeUnfilled = "unfilled"
+
+	// Some source files have a wrong license header
+	codeBadHeader = "bad_header"
+)
+
+// The exit codes of gitgen. Scripts can tell a mistake in the
+// command from a missing template or a file that could not
+// be written
+const (
+	exitOK       = 0
+	exitFailure  = 1
+	exitUsage    = 2
+	exitNotFound = 3
+	exitIO       = 4
+)
+
+// An error of a sub command. The message is what is
+// printed in the text format
+type cliError struct {
+	Code        string   `json:"code"`
+	Message     string   `json:"message"`
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+func (e *cliError) Error() string {
+	return e.Message
+}
+
+// The exit code of the error
+func (e *cliError) exitCode() int {
+	switch e.Code {
+	case codeUsage:
+		return exitUsage
+	case codeNotFound:
+		return exitNotFound
+	case codeIO, codeExists:
+		return exitIO
+	}
+
+	return exitFailure
+}
+
+// Make an error with a formatted message
+func newError(code, format string, a ...interface{}) *cliError {
+	return &cliError{Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+// Make an error for a missing template. The suggestions
+// of err are added to the message and to the error
+func notFoundError(err error, format string, a ...interface{}) *cliError {
+	e := newError(codeNotFound, format, a...)
+	e.Message += didYouMean(err)
+
+	var nf *gitgen.NotFoundError
+
+	if errors.As(err, &nf) {
+		e.Suggestions = nf.Suggestions
+	}
+
+	return e
+}
+
+// Make an error for a file that could not be written or read
+func fileError(err error) *cliError {
+	if errors.Is(err, fs.ErrExist) {
+		return newError(codeExists,
+			"Error: %v. Use --force to overwrite it or --append to add to it",
+			err)
+	}
+
+	return newError(codeIO, "Error: %v", err)
+}
+
+// Print an error in the given format and get its exit code.
+// Errors that are not a *cliError should not happen, but are
+// printed anyway
+func printError(err error, format string, errOut testableWriter) int {
+	var e *cliError
+
+	if !errors.As(err, &e) {
+		e = newError(codeIO, "Error: %v", err)
+	}
+
+	if format == formatJSON {
+		// The prefix is only for people
+		doc := *e
+		doc.Message = strings.TrimPrefix(doc.Message, "Error: ")
+
+		writeJSON(errOut, struct {
+			Error cliError `json:"error"`
+		}{doc})
+
+		return e.exitCode()
+	}
+
+	errOut.WriteString(e.Message)
+
+	return e.exitCode()
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"io"
+)
+
+// Create the flag set of a sub command. Errors are not
+// printed, the caller prints them in its own format
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	return fs
+}
+
+// Parse flags and positional arguments in any order, so both
+// "lic mit -y 2021" and "lic -y 2021 mit" work. The flag package
+// stops at the first positional argument, so parsing starts again
+// after each one. A -- ends the flags. It returns the positional
+// arguments
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		rest := fs.Args()
+
+		// Everything after -- is positional
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+
+		if len(rest) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// Register a string flag with a short and a long name
+func stringFlag(fs *flag.FlagSet, p *string, short, long, usage string) {
+	if short != "" {
+		fs.StringVar(p, short, "", usage)
+	}
+
+	fs.StringVar(p, long, "", usage)
+}
+
+// Register a bool flag with a short and a long name
+func boolFlag(fs *flag.FlagSet, p *bool, short, long, usage string) {
+	if short != "" {
+		fs.BoolVar(p, short, false, usage)
+	}
+
+	fs.BoolVar(p, long, false, usage)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseArgs(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantPositional []string
+		wantYear       string
+		wantForce      bool
+		wantErr        string
+	}{
+		{
+			"Flags after the argument",
+			[]string{"mit", "-y", "2021"},
+			[]string{"mit"}, "2021", false, "",
+		},
+		{
+			"Flags before the argument",
+			[]string{"--year=2021", "-f", "mit"},
+			[]string{"mit"}, "2021", true, "",
+		},
+		{
+			"Flags between arguments",
+			[]string{"a", "--force", "b", "--year", "1999", "c"},
+			[]string{"a", "b", "c"}, "1999", true, "",
+		},
+		{
+			"Double dash ends the flags",
+			[]string{"a", "--", "-y", "b"},
+			[]string{"a", "-y", "b"}, "", false, "",
+		},
+		{
+			"Unknown flag",
+			[]string{"mit", "--wakanda"},
+			nil, "", false, "flag provided but not defined: -wakanda",
+		},
+		{
+			"Missing value",
+			[]string{"mit", "-y"},
+			nil, "", false, "flag needs an argument: -y",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var year string
+			var force bool
+
+			fs := newFlagSet("test")
+			stringFlag(fs, &year, "y", "year", "")
+			boolFlag(fs, &force, "f", "force", "")
+
+			got, err := parseArgs(fs, tt.args)
+
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("parseArgs() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.wantPositional) {
+				t.Errorf("parseArgs() = %v, want %v", got, tt.wantPositional)
+			}
+
+			if year != tt.wantYear || force != tt.wantForce {
+				t.Errorf("Got year %v and force %v, want %v and %v",
+					year, force, tt.wantYear, tt.wantForce)
+			}
+		})
+	}
+}
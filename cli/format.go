@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// The formats of the output, chosen with --format
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// Take the global flags out of the arguments. They can go anywhere
+// before a --, like gitgen --format json ls or gitgen ls --format json.
+// It returns the format and the arguments without the flags
+func globalFlags(args []string) (string, []string, error) {
+	format := formatText
+
+	rest := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		// The rest is not for us
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+
+		switch {
+		case arg == "--format" || arg == "-format":
+			if i+1 == len(args) {
+				return "", nil, newError(codeUsage, "Error: --format needs a value: text or json")
+			}
+
+			i++
+			format = args[i]
+
+		case strings.HasPrefix(arg, "--format="), strings.HasPrefix(arg, "-format="):
+			format = arg[strings.IndexByte(arg, '=')+1:]
+
+		default:
+			rest = append(rest, arg)
+			continue
+		}
+
+		if format != formatText && format != formatJSON {
+			return "", nil, newError(codeUsage, "Error: Unknown format '%v'. Use text or json", format)
+		}
+	}
+
+	return format, rest, nil
+}
+
+// Write a value as indented JSON, ending with a new line
+func writeJSON(out io.Writer, v interface{}) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	// The output is not HTML, and licenses have <placeholders>
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(v)
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_globalFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantFormat string
+		wantArgs   []string
+		wantErr    bool
+	}{
+		{
+			"No flags",
+			[]string{"gg", "ls", "ignore"},
+			formatText, []string{"gg", "ls", "ignore"}, false,
+		},
+
+		{
+			"Before the sub command",
+			[]string{"gg", "--format", "json", "ls", "ignore"},
+			formatJSON, []string{"gg", "ls", "ignore"}, false,
+		},
+
+		{
+			"After the sub command, with =",
+			[]string{"gg", "detect", "--format=json"},
+			formatJSON, []string{"gg", "detect"}, false,
+		},
+
+		{
+			"Not after --",
+			[]string{"gg", "ci", "C", "--", "--format=json"},
+			formatText, []string{"gg", "ci", "C", "--", "--format=json"}, false,
+		},
+
+		{
+			"Unknown format",
+			[]string{"gg", "--format", "xml", "ls"},
+			"", nil, true,
+		},
+
+		{
+			"Missing format",
+			[]string{"gg", "ls", "--format"},
+			"", nil, true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, args, err := globalFlags(tt.args)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("globalFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if format != tt.wantFormat {
+				t.Errorf("globalFlags() format = %v, want %v", format, tt.wantFormat)
+			}
+
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("globalFlags() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func Test_formatJSON(t *testing.T) {
+	dir := makeProject(t)
+	out := filepath.Join(t.TempDir(), ".gitignore")
+
+	tests := []testCase{
+		{
+			"List",
+			[]string{"gg", "--format", "json", "ls", "license", "mit"}, exitOK,
+			"", `[
+  {
+    "name": "mit",
+    "title": "MIT License",
+    "category": "permissive",
+    "source": "embedded"
+  }
+]
+`,
+		},
+
+		{
+			"Detect",
+			[]string{"gg", "detect", dir, "--format", "json"}, exitOK,
+			"", `[
+  {
+    "template": "Go",
+    "marker": "go.mod"
+  },
+  {
+    "template": "Node",
+    "marker": "web/package.json"
+  }
+]
+`,
+		},
+
+		{
+			"Check ignore",
+			[]string{"gg", "--format=json", "ci", "C", "--", "build/out.o", "main.c"}, exitOK,
+			"", `[
+  {
+    "path": "build/out.o",
+    "matched": true,
+    "ignored": true,
+    "source": "C",
+    "line": 5,
+    "pattern": "*.o"
+  },
+  {
+    "path": "main.c",
+    "matched": false,
+    "ignored": false
+  }
+]
+`,
+		},
+
+		{
+			"Write a file",
+			[]string{"gg", "--format", "json", "i", "Yeoman", "-o", out}, exitOK,
+			"", `{
+  "action": "wrote",
+  "path": "` + out + `",
+  "bytes": 52
+}
+`,
+		},
+
+		{
+			"The file exists",
+			[]string{"gg", "--format", "json", "i", "Yeoman", "-o", out}, exitIO,
+			`{
+  "error": {
+    "code": "exists",
+    "message": "` + out + `: file already exists. Use --force to overwrite it or --append to add to it"
+  }
+}
+`, "",
+		},
+
+		{
+			"Unknown license",
+			[]string{"gg", "--format", "json", "lic", "info", "gpl-4.0"}, exitNotFound,
+			`{
+  "error": {
+    "code": "not_found",
+    "message": "Unknown license 'gpl-4.0'. Did you mean gpl-2.0, gpl-3.0?",
+    "suggestions": [
+      "gpl-2.0",
+      "gpl-3.0"
+    ]
+  }
+}
+`, "",
+		},
+
+		{
+			"Usage",
+			[]string{"gg", "--format", "json", "i"}, exitUsage,
+			`{
+  "error": {
+    "code": "usage",
+    "message": "Usage: gg [ignore|gitignore|i] [--auto] [--merge|--managed] [--global|--local] [ignore template...]"
+  }
+}
+`, "",
+		},
+
+		{
+			"Unknown format",
+			[]string{"gg", "--format", "xml", "ls", "ignore"}, exitUsage,
+			"Error: Unknown format 'xml'. Use text or json", "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+}
+
+func Test_formatJSONInfo(t *testing.T) {
+	tstOut := new(strings.Builder)
+
+	if code := cli([]string{"gg", "lic", "info", "mit", "--format", "json"}, tstOut, tstOut); code != exitOK {
+		t.Fatal("Unexpected exit code: ", code)
+	}
+
+	var got map[string]interface{}
+
+	if err := json.Unmarshal([]byte(tstOut.String()), &got); err != nil {
+		t.Fatal("Invalid JSON: ", err)
+	}
+
+	if got["spdx_id"] != "MIT" || got["category"] != "permissive" {
+		t.Errorf("Unexpected info: %v", got)
+	}
+}
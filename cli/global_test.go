@@ -0,0 +1,168 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Run the test in a new directory with its own home, so
+// the git config of the user is not read
+func withHome(t *testing.T) (home, dir string) {
+	home, dir = t.TempDir(), t.TempDir()
+
+	for key, val := range map[string]string{"HOME": home, "XDG_CONFIG_HOME": ""} {
+		old, had := os.LookupEnv(key)
+		os.Setenv(key, val)
+
+		key := key
+
+		t.Cleanup(func() {
+			if had {
+				os.Setenv(key, old)
+			} else {
+				os.Unsetenv(key)
+			}
+		})
+	}
+
+	wd, _ := os.Getwd()
+	os.Chdir(dir)
+
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	return home, dir
+}
+
+func Test_ignoreGlobal(t *testing.T) {
+	home, _ := withHome(t)
+
+	excludes := filepath.Join(home, ".config", "git", "ignore")
+
+	tests := []testCase{
+		{
+			"Create the excludes file",
+			[]string{"gg", "i", "--global", "macOS", "vscode"}, exitOK,
+			"", "Added 25 rules to " + excludes + "\n",
+		},
+
+		{
+			"Nothing new",
+			[]string{"gg", "i", "--global", "osx"}, exitOK,
+			"", excludes + " is up to date\n",
+		},
+
+		{
+			"Both",
+			[]string{"gg", "i", "--global", "--local", "Vim"}, exitUsage,
+			"Error: --global and --local can not be used together", "",
+		},
+
+		{
+			"With an output",
+			[]string{"gg", "i", "--global", "Vim", "-o", "ignore"}, exitUsage,
+			"Error: --global and --local can not be used with --output or --write", "",
+		},
+
+		{
+			"Local outside a repository",
+			[]string{"gg", "i", "--local", "Vim"}, exitIO,
+			"Error: no git repository found: file does not exist", "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+}
+
+func Test_ignoreGlobalManaged(t *testing.T) {
+	home, _ := withHome(t)
+
+	excludes := filepath.Join(home, ".config", "git", "ignore")
+
+	tests := []testCase{
+		{
+			"Add a block",
+			[]string{"gg", "i", "--global", "--managed", "macOS"}, exitOK,
+			"", "Added macOS to " + excludes + "\n",
+		},
+
+		{
+			"Keep the block",
+			[]string{"gg", "i", "--global", "--managed", "osx", "Vim"}, exitOK,
+			"", "Added Vim to " + excludes + "\n",
+		},
+
+		{
+			"Nothing new",
+			[]string{"gg", "i", "--global", "--managed", "macOS", "Vim"}, exitOK,
+			"", excludes + " is up to date\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+
+	data, _ := os.ReadFile(excludes)
+
+	if n := strings.Count(string(data), "# >>> gitgen macOS >>>"); n != 1 {
+		t.Errorf("The excludes file has %d macOS blocks: %q", n, data)
+	}
+
+	// Outdated blocks are updated in place
+	os.WriteFile(excludes, []byte("# >>> gitgen macOS >>>\n.DS_Store\n# <<< gitgen macOS <<<\n"), 0644)
+
+	tt := testCase{
+		"Update the block",
+		[]string{"gg", "i", "--global", "--managed", "macOS"}, exitOK,
+		"", "Updated macOS in " + excludes + "\n",
+	}
+
+	tt.runTest(t)
+
+	data, _ = os.ReadFile(excludes)
+
+	if !strings.Contains(string(data), "\nIcon\r\r\n") || strings.Count(string(data), "# >>> gitgen") != 1 {
+		t.Errorf("Unexpected excludes file: %q", data)
+	}
+}
+
+func Test_ignoreGlobalConfigured(t *testing.T) {
+	home, _ := withHome(t)
+
+	os.WriteFile(filepath.Join(home, ".gitconfig"),
+		[]byte("[core]\n\texcludesFile = ~/.gitignore_global\n"), 0644)
+
+	tt := testCase{
+		"Use core.excludesFile",
+		[]string{"gg", "i", "--global", "Linux"}, exitOK,
+		"", "Added 5 rules to " + filepath.Join(home, ".gitignore_global") + "\n",
+	}
+
+	tt.runTest(t)
+}
+
+func Test_ignoreLocal(t *testing.T) {
+	_, dir := withHome(t)
+
+	os.Mkdir(filepath.Join(dir, ".git"), 0755)
+
+	exclude := filepath.Join(dir, ".git", "info", "exclude")
+
+	tt := testCase{
+		"Create .git/info/exclude",
+		[]string{"gg", "i", "--local", "JetBrains"}, exitOK,
+		"", "Added 30 rules to " + exclude + "\n",
+	}
+
+	tt.runTest(t)
+
+	data, _ := os.ReadFile(exclude)
+
+	if len(data) == 0 || string(data[:18]) != "### JetBrains ###\n" {
+		t.Errorf("Unexpected exclude file: %q", data)
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.eduardoandres.dev/gitgen"
+)
+
+const headerHelp = `License headers:
+	Add a comment with the copyright and the SPDX identifier of a
+	license to the top of the source files. Paths work like the
+	packages of go: ./... is the current folder and all its
+	subfolders. Generated files and files that already have a
+	header are skipped. Shebangs stay in the first line, and
+	Python encoding declarations in the first two
+	Flags:
+		--license string
+			The license of the files. Required
+		--holder string
+			The copyright holder. Your git user.name by default
+		-y, --year string
+			The year of the copyright. This one by default
+		--email, --project, --description, --org string
+			Like in gitgen lic. The GNU licenses need the project
+	Examples:
+		gitgen header add --license apache-2.0 --holder "ACME" ./...
+		gitgen header add --license gpl-3.0 --project gitgen cmd/...
+
+	Check the headers in CI: print every source file without an SPDX
+	header, with another license or with a copyright line without a
+	year and a holder, and fail if there is any. The -only and
+	-or-later identifiers of the license are fine, like
+	GPL-3.0-only, and so are OR expressions with it, like
+	MIT OR Apache-2.0, but not AND or WITH. Files ignored by git
+	are skipped. Paths are ./... by default
+	Flags:
+		--license string
+			The license of the project. Required
+		-y, --year string
+			Also fail if a copyright does not reach this year
+		--github
+			Print the problems as GitHub Actions annotations
+	Examples:
+		gitgen header check --license apache-2.0
+		gitgen header check --license mit --year 2021 --github ./...
+		gitgen --format json header check --license mit`
+
+// Run the header sub command. The args start
+// after the sub command itself
+func header(program string, args []string, format string, out testableWriter) error {
+	if len(args) != 0 && args[0] == "add" {
+		return headerAdd(program, args[1:], format, out)
+	}
+
+	if len(args) != 0 && args[0] == "check" {
+		return headerCheck(program, args[1:], format, out)
+	}
+
+	return newError(codeUsage, "Error: Incomplete command. Usage: %v header add|check --license key [paths...]", program)
+}
+
+// Add headers to the files of some paths
+func headerAdd(program string, args []string, format string, out testableWriter) error {
+	var l licenseFlags
+	var key string
+
+	fs := newFlagSet("header")
+
+	stringFlag(fs, &key, "", "license", "The license of the files")
+	stringFlag(fs, &l.name, "", "holder", "The copyright holder")
+	stringFlag(fs, &l.year, "y", "year", "The year of the copyright")
+	stringFlag(fs, &l.email, "", "email", "The email of the copyright holder")
+	stringFlag(fs, &l.project, "", "project", "The name of the project")
+	stringFlag(fs, &l.description, "", "description", "A brief idea of what the project does")
+	stringFlag(fs, &l.org, "", "org", "The organization that owns the copyright")
+
+	paths, err := parseArgs(fs, args)
+
+	if err == nil && key == "" {
+		err = errors.New("the license is missing")
+	}
+
+	if err == nil && len(paths) == 0 {
+		err = errors.New("no paths")
+	}
+
+	if err != nil {
+		return newError(codeUsage, "Error: %v. Usage: %v header add --license key [paths...]", err, program)
+	}
+
+	text, err := gitgen.HeaderText(key, licenseDefaults().ApplyParams(l.params()))
+
+	var pe *gitgen.PlaceholderError
+
+	switch {
+	case errors.As(err, &pe):
+		return newError(codeUnfilled,
+			"Error: The header of '%v' has unfilled placeholders: %v. Fill them with flags like --holder, --year or --project",
+			pe.Key, strings.Join(pe.Placeholders, ", "))
+
+	case err != nil:
+		return licenseError(key, err)
+	}
+
+	results, err := gitgen.AddHeaders(text, paths...)
+
+	if err != nil {
+		return fileError(err)
+	}
+
+	if format == formatJSON {
+		return writeJSON(out, results)
+	}
+
+	added, present := 0, 0
+
+	for _, r := range results {
+		switch r.Status {
+		case gitgen.HeaderAdded:
+			added++
+			fmt.Fprintf(out, "Added a header to %v\n", r.Path)
+
+		case gitgen.HeaderPresent:
+			present++
+		}
+	}
+
+	fmt.Fprintf(out, "Added %d headers, %d files already had one\n", added, present)
+
+	return nil
+}
+
+// Check the headers of the files of some paths
+func headerCheck(program string, args []string, format string, out testableWriter) error {
+	var key, year string
+	var github bool
+
+	fs := newFlagSet("header")
+
+	stringFlag(fs, &key, "", "license", "The license of the project")
+	stringFlag(fs, &year, "y", "year", "The year the copyrights must reach")
+	boolFlag(fs, &github, "", "github", "Print GitHub Actions annotations")
+
+	paths, err := parseArgs(fs, args)
+
+	if err == nil && key == "" {
+		err = errors.New("the license is missing")
+	}
+
+	policy := gitgen.HeaderPolicy{License: key}
+
+	if err == nil && year != "" {
+		if policy.Year, err = strconv.Atoi(year); err != nil {
+			err = fmt.Errorf("invalid year '%v'", year)
+		}
+	}
+
+	if err != nil {
+		return newError(codeUsage, "Error: %v. Usage: %v header check --license key [paths...]", err, program)
+	}
+
+	if len(paths) == 0 {
+		paths = []string{"./..."}
+	}
+
+	problems, err := gitgen.CheckHeaders(policy, paths...)
+
+	switch {
+	case errors.Is(err, gitgen.ErrTemplateNotFound):
+		return notFoundError(err, "Error: Unknown license '%v'", key)
+
+	case err != nil:
+		return fileError(err)
+	}
+
+	switch {
+	case format == formatJSON:
+		// Always an array, even without problems
+		if problems == nil {
+			problems = []gitgen.HeaderProblem{}
+		}
+
+		writeJSON(out, problems)
+
+	case github:
+		for _, p := range problems {
+			fmt.Fprintf(out, "::error file=%v,line=%d,title=License header::%v\n",
+				escapeProperty(p.Path), p.Line, escapeData(p.Message))
+		}
+
+	default:
+		for _, p := range problems {
+			fmt.Fprintf(out, "%v:%d: %v\n", p.Path, p.Line, p.Message)
+		}
+	}
+
+	switch len(problems) {
+	case 0:
+		return nil
+	case 1:
+		return newError(codeBadHeader, "Error: Found 1 problem in the license headers")
+	}
+
+	return newError(codeBadHeader, "Error: Found %d problems in the license headers", len(problems))
+}
+
+// Escape the message of a GitHub Actions annotation
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// Escape a property of a GitHub Actions annotation, like the file
+func escapeProperty(s string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(escapeData(s))
+}
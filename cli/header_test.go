@@ -0,0 +1,214 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_subcommandHeader(t *testing.T) {
+	dir := t.TempDir()
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+
+	os.Chdir(dir)
+
+	os.MkdirAll(filepath.Join("cmd", "app"), 0755)
+	os.WriteFile("main.go", []byte("package main\n"), 0644)
+	os.WriteFile("README.md", []byte("# App\n"), 0644)
+	os.WriteFile(filepath.Join("cmd", "app", "run.py"), []byte("#!/usr/bin/env python3\nprint('hi')\n"), 0755)
+
+	goHeader := "// Copyright (c) 2021 ACME\n//\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n"
+
+	tests := []testCase{
+		{
+			"Without add",
+			[]string{"gg", "header"}, exitUsage,
+			"Error: Incomplete command. Usage: gg header add|check --license key [paths...]", "",
+		},
+
+		{
+			"Without the license",
+			[]string{"gg", "header", "add", "./..."}, exitUsage,
+			"Error: the license is missing. Usage: gg header add --license key [paths...]", "",
+		},
+
+		{
+			"Without paths",
+			[]string{"gg", "header", "add", "--license", "mit"}, exitUsage,
+			"Error: no paths. Usage: gg header add --license key [paths...]", "",
+		},
+
+		{
+			"Unknown license",
+			[]string{"gg", "header", "add", "--license", "mti", "./..."}, exitNotFound,
+			"Error: Unknown license 'mti'. Did you mean mit?", "",
+		},
+
+		{
+			"GNU license without the project",
+			[]string{"gg", "header", "add", "--license", "gpl-3.0", "./..."}, exitFailure,
+			"Error: The header of 'gpl-3.0' has unfilled placeholders: " +
+				"<one line to give the program's name and a brief idea of what it does.>. " +
+				"Fill them with flags like --holder, --year or --project",
+			"",
+		},
+
+		{
+			"Missing path",
+			[]string{"gg", "header", "add", "--license", "mit", "nope/..."}, exitIO,
+			"Error: stat nope: no such file or directory", "",
+		},
+
+		{
+			"Add headers",
+			[]string{"gg", "header", "add", "--license", "apache-2.0", "--holder", "ACME", "./..."}, exitOK,
+			"", "Added a header to " + filepath.Join("cmd", "app", "run.py") + "\n" +
+				"Added a header to main.go\n" +
+				"Added 2 headers, 0 files already had one\n",
+		},
+
+		{
+			"Add them again",
+			[]string{"gg", "header", "add", "--license", "apache-2.0", "--holder", "ACME", "./..."}, exitOK,
+			"", "Added 0 headers, 2 files already had one\n",
+		},
+
+		{
+			"JSON",
+			[]string{"gg", "--format", "json", "header", "add", "--license", "mit", "main.go"}, exitOK,
+			"", `[
+  {
+    "path": "main.go",
+    "status": "present"
+  }
+]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+
+	data, _ := os.ReadFile("main.go")
+
+	if got := string(data); got != goHeader {
+		t.Errorf("main.go has %q", got)
+	}
+
+	data, _ = os.ReadFile(filepath.Join("cmd", "app", "run.py"))
+
+	if !strings.HasPrefix(string(data), "#!/usr/bin/env python3\n# Copyright (c) 2021 ACME\n") {
+		t.Errorf("run.py has %q", data)
+	}
+}
+
+func Test_subcommandHeaderCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+
+	os.Chdir(dir)
+
+	os.Mkdir(".git", 0755)
+	os.WriteFile(".gitignore", []byte("/out/\n"), 0644)
+	os.Mkdir("out", 0755)
+	os.WriteFile(filepath.Join("out", "bundle.js"), []byte("var x\n"), 0644)
+	os.WriteFile("good.go", []byte("// Copyright 2021 ACME\n// SPDX-License-Identifier: MIT\n\npackage main\n"), 0644)
+	os.WriteFile("old.go", []byte("// Copyright 2019 ACME\n// SPDX-License-Identifier: MIT\n\npackage main\n"), 0644)
+	os.WriteFile("apache.go", []byte("// Copyright 2021 ACME\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n"), 0644)
+	os.WriteFile("dual.go", []byte("// Copyright 2021 ACME\n// SPDX-License-Identifier: MIT OR Apache-2.0\n\npackage main\n"), 0644)
+	os.WriteFile("bare.py", []byte("print('hi')\n"), 0644)
+
+	failed := "Error: Found 2 problems in the license headers"
+
+	tests := []testCase{
+		{
+			"Without the license",
+			[]string{"gg", "header", "check"}, exitUsage,
+			"Error: the license is missing. Usage: gg header check --license key [paths...]", "",
+		},
+
+		{
+			"Bad year",
+			[]string{"gg", "header", "check", "--license", "mit", "-y", "last"}, exitUsage,
+			"Error: invalid year 'last'. Usage: gg header check --license key [paths...]", "",
+		},
+
+		{
+			"Unknown license",
+			[]string{"gg", "header", "check", "--license", "mti"}, exitNotFound,
+			"Error: Unknown license 'mti'. Did you mean mit?", "",
+		},
+
+		{
+			"Text",
+			[]string{"gg", "header", "check", "--license", "mit"}, exitFailure,
+			failed,
+			"apache.go:2: The license is Apache-2.0 instead of MIT\n" +
+				"bare.py:1: There is no SPDX-License-Identifier\n",
+		},
+
+		{
+			"Stale years",
+			[]string{"gg", "header", "check", "--license", "mit", "--year", "2021", "old.go", "good.go"}, exitFailure,
+			"Error: Found 1 problem in the license headers",
+			"old.go:1: The copyright ends in 2019 instead of 2021\n",
+		},
+
+		{
+			"OR expressions",
+			[]string{"gg", "header", "check", "--license", "apache-2.0", "dual.go", "apache.go"}, exitOK,
+			"", "",
+		},
+
+		{
+			"GitHub annotations",
+			[]string{"gg", "header", "check", "--license", "mit", "--github", "./..."}, exitFailure,
+			failed,
+			"::error file=apache.go,line=2,title=License header::The license is Apache-2.0 instead of MIT\n" +
+				"::error file=bare.py,line=1,title=License header::There is no SPDX-License-Identifier\n",
+		},
+
+		{
+			"JSON",
+			[]string{"gg", "--format", "json", "header", "check", "--license", "mit", "bare.py"}, exitFailure,
+			`{
+  "error": {
+    "code": "bad_header",
+    "message": "Found 1 problem in the license headers"
+  }
+}
+`,
+			`[
+  {
+    "path": "bare.py",
+    "line": 1,
+    "kind": "missing",
+    "message": "There is no SPDX-License-Identifier"
+  }
+]
+`,
+		},
+
+		{
+			"No problems",
+			[]string{"gg", "--format", "json", "header", "check", "--license", "mit", "good.go"}, exitOK,
+			"", "[]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+}
+
+func Test_escapeProperty(t *testing.T) {
+	if got := escapeProperty("a:b,c%d\ne"); got != "a%3Ab%2Cc%25d%0Ae" {
+		t.Errorf("escapeProperty() = %v", got)
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"go.eduardoandres.dev/gitgen"
+)
+
+// Where the year and the name come from when they are not given.
+// Tests replace it to avoid depending on the clock and the user
+var licenseDefaults = func() gitgen.Defaults {
+	return gitgen.SystemDefaults(".")
+}
+
+// The parameters of a license given as flags
+type licenseFlags struct {
+	year, name, email, project, description, url, org string
+
+	// Write the license even if it has unfilled placeholders
+	allowPlaceholders bool
+
+	// Write the short copyright notice instead of the license
+	notice bool
+}
+
+// Run the license sub command. The args start
+// after the sub command itself
+func license(program string, args []string, format string, out testableWriter) error {
+	var l licenseFlags
+	var o outputOptions
+
+	fs := newFlagSet("license")
+	o.register(fs)
+
+	stringFlag(fs, &l.year, "y", "year", "The year of the copyright")
+	stringFlag(fs, &l.name, "n", "name", "The name of the copyright holder")
+	stringFlag(fs, &l.email, "", "email", "The email of the copyright holder")
+	stringFlag(fs, &l.project, "", "project", "The name of the project")
+	stringFlag(fs, &l.description, "", "description", "A brief idea of what the project does")
+	stringFlag(fs, &l.url, "", "url", "The home page of the project")
+	stringFlag(fs, &l.org, "", "org", "The organization that owns the copyright")
+	boolFlag(fs, &l.allowPlaceholders, "", "allow-placeholders", "Write the license even if it has unfilled placeholders")
+	boolFlag(fs, &l.notice, "", "notice", "Write the short copyright notice instead of the license")
+
+	params, err := parseArgs(fs, args)
+
+	if err != nil {
+		return newError(codeUsage, "Error: %v", err)
+	}
+
+	// Incomplete command
+	if len(params) == 0 {
+		return newError(codeUsage, "Error: Incomplete command. Usage: %v [license|lic|l] [license name] (optional flags -y year -n name)", program)
+	}
+
+	switch params[0] {
+	case "info":
+		// Print the metadata instead of the license
+		return licenseInfo(program, params[1:], format, out)
+
+	case "choose":
+		return chooseLicense(l, o, format, out)
+	}
+
+	// The old positional form: license year name
+	if len(params) == 3 && l.year == "" && l.name == "" {
+		l.year, l.name = params[1], params[2]
+		params = params[:1]
+	}
+
+	if len(params) != 1 {
+		return newError(codeUsage, "Error: Unexpected argument '%v'", params[1])
+	}
+
+	// Write the license to the out (either test, stdout, a
+	// file, etc) given the flags and the argument
+	return licenseError(params[0], l.emit(params[0], o, format, out))
+}
+
+// Write a license to the output or to a file, like emit. The GNU
+// licenses are written verbatim, so when they are written to a
+// file with a copyright in the flags, its notice goes to the
+// COPYRIGHT next to it
+func (l licenseFlags) emit(key string, o outputOptions, format string, out testableWriter) error {
+	// The notice goes to COPYRIGHT with -w
+	defaultName := "LICENSE"
+
+	if l.notice {
+		defaultName = "COPYRIGHT"
+	}
+
+	write := func(w io.Writer) (int, error) {
+		return l.write(key, w)
+	}
+
+	if !l.needsNotice(key) || (o.path == "" && !o.repo) {
+		return emit(o, defaultName, write, format, out)
+	}
+
+	// Fill the notice first, so nothing is written if it fails
+	n := l
+	n.notice = true
+
+	notice := new(bytes.Buffer)
+
+	if _, err := n.write(key, notice); err != nil {
+		return err
+	}
+
+	path := o.target(defaultName)
+	copyrightPath := filepath.Join(filepath.Dir(path), "COPYRIGHT")
+
+	// Check both files before writing any, so
+	// the license is not left without its notice
+	for _, p := range []string{path, copyrightPath} {
+		if err := checkTarget(p, o.file); err != nil {
+			return err
+		}
+	}
+
+	lic, err := writeOutput(path, o.file, write)
+
+	if err != nil {
+		return err
+	}
+
+	copyright, err := writeOutput(copyrightPath, o.file, func(w io.Writer) (int, error) {
+		return w.Write(notice.Bytes())
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return printWrites(format, out, lic, copyright)
+}
+
+// Tell if the copyright of the flags goes in the notice, as
+// the license is written verbatim, like the GPL
+func (l licenseFlags) needsNotice(key string) bool {
+	given := l.year != "" || l.name != "" || l.email != "" || l.project != "" ||
+		l.description != "" || l.url != "" || l.org != ""
+
+	return given && !l.notice && gitgen.HasNotice(key)
+}
+
+// Make the error of writing a license, or nil if there is none
+func licenseError(key string, err error) error {
+	// If the license does not exist,
+	// the error wraps gitgen.ErrTemplateNotFound
+	var pe *gitgen.PlaceholderError
+
+	switch {
+	case errors.Is(err, gitgen.ErrTemplateNotFound):
+		return notFoundError(err, "Error: Unknown license '%v'", key)
+
+	case errors.As(err, &pe):
+		return newError(codeUnfilled,
+			"Error: The license '%v' has unfilled placeholders: %v. Fill them with flags like --name, --year or --project, or use --allow-placeholders",
+			pe.Key, strings.Join(pe.Placeholders, ", "))
+
+	case err != nil:
+		return fileError(err)
+	}
+
+	return nil
+}
+
+// Write a license, or its notice, with the parameters that were
+// given. The year and the name default to the current year and
+// user.name. Unless it is allowed, nothing is written if a
+// placeholder is left unfilled
+func (l licenseFlags) write(key string, w io.Writer) (int, error) {
+	params := licenseDefaults().ApplyParams(l.params())
+
+	switch {
+	case l.notice && l.allowPlaceholders:
+		return gitgen.RenderNotice(key, params, w)
+	case l.notice:
+		return gitgen.RenderNoticeStrict(key, params, w)
+	case l.allowPlaceholders:
+		return gitgen.RenderLicense(key, params, w)
+	}
+
+	return gitgen.RenderLicenseStrict(key, params, w)
+}
+
+// The parameters of the license
+func (l licenseFlags) params() gitgen.LicenseParams {
+	p := gitgen.LicenseParams{
+		Year:         l.year,
+		Email:        l.email,
+		Project:      l.project,
+		Description:  l.description,
+		URL:          l.url,
+		Organization: l.org,
+	}
+
+	if l.name != "" {
+		p.Holders = []string{l.name}
+	}
+
+	return p
+}
+
+// Print the metadata of a license
+func licenseInfo(program string, args []string, format string, out testableWriter) error {
+	if len(args) != 1 {
+		return newError(codeUsage, "Usage: %v license info [license name]", program)
+	}
+
+	lic, err := gitgen.LicenseInfo(args[0])
+
+	if err != nil {
+		return notFoundError(err, "Error: Unknown license '%v'", args[0])
+	}
+
+	if format == formatJSON {
+		return writeJSON(out, struct {
+			gitgen.License
+			Category string `json:"category"`
+		}{lic, lic.Category()})
+	}
+
+	fmt.Fprintf(out, "%v (%v)\n", lic.Name, lic.Key)
+	fmt.Fprintf(out, "SPDX: %v\n", lic.SPDX)
+
+	if lic.Nickname != "" {
+		fmt.Fprintf(out, "Nickname: %v\n", lic.Nickname)
+	}
+
+	fmt.Fprintf(out, "\n%v\n\n", lic.Description)
+
+	fmt.Fprintf(out, "Permissions: %v\n", listOrNone(lic.Permissions))
+	fmt.Fprintf(out, "Conditions: %v\n", listOrNone(lic.Conditions))
+	fmt.Fprintf(out, "Limitations: %v\n", listOrNone(lic.Limitations))
+	fmt.Fprintf(out, "OSI approved: %v\n", yesNo(lic.OSIApproved))
+	fmt.Fprintf(out, "FSF libre: %v\n", yesNo(lic.FSFLibre))
+	fmt.Fprintf(out, "Placeholders: %v\n", listOrNone(lic.Placeholders))
+
+	return nil
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+
+	return strings.Join(items, ", ")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.eduardoandres.dev/gitgen"
+)
+
+// Use a fixed year and name in every test
+func init() {
+	licenseDefaults = func() gitgen.Defaults {
+		return gitgen.Defaults{
+			Now: func() time.Time {
+				return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			},
+			Config: gitgen.GitConfig{"user.name": "Eduardo Castillo"},
+		}
+	}
+}
+
+func Test_subcommandLicenseFlags(t *testing.T) {
+	tests := []testCase{
+		{
+			"Flags after the license",
+			[]string{"xd", "lic", "mit", "-y", "2021", "-n", "Eduardo Castillo"},
+			exitOK, "", fullMITWithParams,
+		},
+
+		{
+			"Flags before the license, in another order",
+			[]string{"xd", "lic", "-n", "Eduardo Castillo", "--year", "2021", "mit"},
+			exitOK, "", fullMITWithParams,
+		},
+
+		{
+			"Flags with equals",
+			[]string{"xd", "lic", "mit", "--name=Eduardo Castillo", "--year=2021"},
+			exitOK, "", fullMITWithParams,
+		},
+
+		{
+			"Email",
+			[]string{"xd", "lic", "mit", "-y", "2021", "-n", "Eduardo Castillo", "--email", "e@x.dev"},
+			exitOK, "",
+			strings.Replace(fullMITWithParams, "Eduardo Castillo", "Eduardo Castillo <e@x.dev>", 1),
+		},
+
+		{
+			"Defaults for the year and the name",
+			[]string{"xd", "lic", "mit"},
+			exitOK, "", fullMITWithParams,
+		},
+
+		{
+			"Default year only",
+			[]string{"xd", "lic", "mit", "-n", "eacp"},
+			exitOK, "",
+			strings.Replace(fullMITWithParams, "Eduardo Castillo", "eacp", 1),
+		},
+
+		{
+			"Info for a license",
+			[]string{"xd", "lic", "info", "mit"},
+			exitOK, "", mitInfo,
+		},
+
+		{
+			"Info for an unknown license",
+			[]string{"xd", "lic", "info", "lol"}, exitNotFound,
+			"Error: Unknown license 'lol'", "",
+		},
+
+		{
+			"Info without a license",
+			[]string{"xd", "lic", "info"}, exitUsage,
+			"Usage: xd license info [license name]", "",
+		},
+
+		{
+			"Unknown flag",
+			[]string{"xd", "lic", "mit", "--wakanda", "forever"}, exitUsage,
+			"Error: flag provided but not defined: -wakanda", "",
+		},
+
+		{
+			"Flag without value",
+			[]string{"xd", "lic", "mit", "-y"}, exitUsage,
+			"Error: flag needs an argument: -y", "",
+		},
+
+		{
+			"Too many arguments",
+			[]string{"xd", "lic", "mit", "apache-2.0"}, exitUsage,
+			"Error: Unexpected argument 'apache-2.0'", "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+}
+
+func Test_subcommandLicenseProject(t *testing.T) {
+	tstOut := new(strings.Builder)
+	tstErr := new(strings.Builder)
+
+	cli([]string{"xd", "lic", "gpl-3.0", "--notice", "--project", "gitgen",
+		"--description", "Generate files for git"}, tstOut, tstErr)
+
+	if tstErr.Len() != 0 {
+		t.Fatal("Unexpected error: ", tstErr.String())
+	}
+
+	got := tstOut.String()
+
+	if !strings.HasPrefix(got, "gitgen: Generate files for git\nCopyright (C) 2021  Eduardo Castillo\n") {
+		t.Errorf("The notice was not filled: %q", got)
+	}
+
+	// The license itself is verbatim
+	tstOut.Reset()
+	cli([]string{"xd", "lic", "gpl-3.0", "--project", "gitgen"}, tstOut, tstErr)
+
+	if tstOut.String() != gitgen.GetLicenseText("gpl-3.0") {
+		t.Error("The GPL was not written verbatim")
+	}
+}
+
+func Test_subcommandLicenseVerbatim(t *testing.T) {
+	// The GPL is printed as it is, and nothing goes to stderr
+	tt := testCase{
+		"Verbatim with a copyright",
+		[]string{"xd", "lic", "gpl-3.0", "-n", "ACME", "-y", "2020"}, exitOK,
+		"", gitgen.GetLicenseText("gpl-3.0"),
+	}
+
+	tt.runTest(t)
+}
+
+func Test_subcommandLicenseVerbatimFile(t *testing.T) {
+	dir := t.TempDir()
+	license := filepath.Join(dir, "LICENSE")
+	copyright := filepath.Join(dir, "COPYRIGHT")
+
+	tests := []testCase{
+		{
+			"Unfilled notice",
+			[]string{"xd", "lic", "gpl-3.0", "-n", "ACME", "-o", license}, exitFailure,
+			"Error: The license 'gpl-3.0' has unfilled placeholders: " +
+				"<one line to give the program's name and a brief idea of what it does.>. " +
+				"Fill them with flags like --name, --year or --project, or use --allow-placeholders",
+			"",
+		},
+
+		{
+			"The license and its notice",
+			[]string{"xd", "lic", "gpl-3.0", "-n", "ACME", "-y", "2020", "--project", "gitgen", "-o", license}, exitOK,
+			"", "Wrote 35149 bytes to " + license + "\n" +
+				"Wrote 646 bytes to " + copyright + "\n",
+		},
+
+		{
+			"Do not overwrite",
+			[]string{"xd", "lic", "gpl-3.0", "-n", "ACME", "--project", "gitgen", "-o", license}, exitIO,
+			"Error: " + license + ": file already exists. Use --force to overwrite it or --append to add to it",
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+
+	// Nothing is written if the COPYRIGHT is already there
+	other := t.TempDir()
+	os.WriteFile(filepath.Join(other, "COPYRIGHT"), []byte("Ours\n"), 0644)
+
+	tt := testCase{
+		"Existing COPYRIGHT",
+		[]string{"xd", "lic", "gpl-3.0", "-n", "ACME", "--project", "gitgen", "-o", filepath.Join(other, "LICENSE")}, exitIO,
+		"Error: " + filepath.Join(other, "COPYRIGHT") + ": file already exists. Use --force to overwrite it or --append to add to it",
+		"",
+	}
+
+	tt.runTest(t)
+
+	if _, err := os.Stat(filepath.Join(other, "LICENSE")); err == nil {
+		t.Error("The LICENSE was written without its notice")
+	}
+
+	data, _ := os.ReadFile(license)
+
+	if string(data) != gitgen.GetLicenseText("gpl-3.0") {
+		t.Error("The GPL was not written verbatim")
+	}
+
+	data, _ = os.ReadFile(copyright)
+
+	if !strings.HasPrefix(string(data), "gitgen\nCopyright (C) 2020  ACME\n") {
+		t.Errorf("The COPYRIGHT has %q", data)
+	}
+}
+
+func Test_subcommandLicenseOrg(t *testing.T) {
+	// The defaults have a name, but the organization is the holder
+	tt := testCase{
+		"Organization",
+		[]string{"xd", "lic", "mit", "--org", "Acme, Inc."}, exitOK,
+		"", strings.Replace(fullMITWithParams, "Eduardo Castillo", "Acme, Inc.", 1),
+	}
+
+	tt.runTest(t)
+}
+
+func Test_subcommandLicensePlaceholders(t *testing.T) {
+	gplLeft := "<one line to give the program's name and a brief idea of what it does.>"
+
+	tests := []testCase{
+		{
+			"GPL notice without the project",
+			[]string{"xd", "lic", "gpl-3.0", "--notice"}, exitFailure,
+			"Error: The license 'gpl-3.0' has unfilled placeholders: " + gplLeft +
+				". Fill them with flags like --name, --year or --project, or use --allow-placeholders",
+			"",
+		},
+
+		{
+			"Placeholders in JSON",
+			[]string{"xd", "--format", "json", "lic", "gpl-3.0", "--notice"}, exitFailure,
+			`{
+  "error": {
+    "code": "unfilled",
+    "message": "The license 'gpl-3.0' has unfilled placeholders: ` + gplLeft +
+				`. Fill them with flags like --name, --year or --project, or use --allow-placeholders"
+  }
+}
+`, "",
+		},
+
+		{
+			"Notice of a license without its own",
+			[]string{"xd", "lic", "mit", "--notice"}, exitOK,
+			"", "Copyright (c) 2021 Eduardo Castillo\n\nSPDX-License-Identifier: MIT\n",
+		},
+
+		{
+			"Placeholders allowed",
+			[]string{"xd", "lic", "mit", "--allow-placeholders"}, exitOK,
+			"", fullMITWithParams,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+
+	// Without user.name there is no holder
+	defaults := licenseDefaults
+
+	licenseDefaults = func() gitgen.Defaults {
+		d := defaults()
+		d.Config = gitgen.GitConfig{}
+
+		return d
+	}
+
+	defer func() { licenseDefaults = defaults }()
+
+	tests = []testCase{
+		{
+			"MIT without a name",
+			[]string{"xd", "lic", "mit"}, exitFailure,
+			"Error: The license 'mit' has unfilled placeholders: [fullname]. " +
+				"Fill them with flags like --name, --year or --project, or use --allow-placeholders",
+			"",
+		},
+
+		{
+			"MIT without a name, allowed",
+			[]string{"xd", "lic", "mit", "--allow-placeholders"}, exitOK,
+			"", strings.Replace(fullMITWithParams, "Eduardo Castillo", "[fullname]", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+}
+
+const mitInfo = `MIT License (mit)
+SPDX: MIT
+
+A short and simple permissive license with conditions only requiring preservation of copyright and license notices. Licensed works, modifications, and larger works may be distributed under different terms and without source code.
+
+Permissions: commercial-use, modifications, distribution, private-use
+Conditions: include-copyright
+Limitations: liability, warranty
+OSI approved: yes
+FSF libre: yes
+Placeholders: [year], [fullname]
+`
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"go.eduardoandres.dev/gitgen"
+)
+
+const lsHelp = `List template files:
+	Print the available .gitignore and license templates, by
+	the key used to generate them. An optional filter keeps
+	only the templates that contain it, ignoring the case
+	Flags:
+		-l, --long
+			Print the name, the title, the category and
+			the source: embedded or a template directory
+		--json
+			Print a JSON array of objects with the name,
+			the title, the category and the source
+	Examples:
+		gitgen ls license
+		gitgen ls ignore
+		gitgen ls ignore py
+		gitgen ls --long license
+		gitgen ls --json ignore`
+
+// The default width of the terminal if COLUMNS is not set
+const defaultColumns = 80
+
+// A template in the output of gitgen ls
+type listEntry struct {
+	Name     string `json:"name"`
+	Title    string `json:"title"`
+	Category string `json:"category"`
+	Source   string `json:"source"`
+}
+
+// Run the list sub command. The args start
+// after the sub command itself
+func list(program string, args []string, format string, out testableWriter) error {
+	var long, asJSON bool
+
+	fs := newFlagSet("list")
+
+	boolFlag(fs, &long, "l", "long", "Print the name, the title, the category and the source")
+	boolFlag(fs, &asJSON, "", "json", "Print JSON")
+
+	rest, err := parseArgs(fs, args)
+
+	if err != nil {
+		return newError(codeUsage, "Error: %v", err)
+	}
+
+	usage := newError(codeUsage, "Usage: %v [list|ls] [--long|--json] [ignore|i|license|l] [filter]", program)
+
+	// Bad usage
+	if len(rest) == 0 || len(rest) > 2 {
+		return usage
+	}
+
+	var entries []listEntry
+
+	switch rest[0] {
+	case "ignore", "i":
+		entries = ignoreEntries()
+	case "license", "lic", "l":
+		entries = licenseEntries()
+	default:
+		return usage
+	}
+
+	if len(rest) == 2 {
+		entries = filterEntries(entries, rest[1])
+	}
+
+	switch {
+	case asJSON || format == formatJSON:
+		// Always an array, even if nothing matched
+		if entries == nil {
+			entries = []listEntry{}
+		}
+
+		return writeJSON(out, entries)
+
+	case long:
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+		for _, e := range entries {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", e.Name, e.Title, e.Category, e.Source)
+		}
+
+		w.Flush()
+
+	case isTerminal(out):
+		out.WriteString(columns(entryNames(entries), terminalWidth()))
+
+	default:
+		// One per line, easy to use in scripts
+		for _, e := range entries {
+			fmt.Fprintln(out, e.Name)
+		}
+	}
+
+	return nil
+}
+
+// The gitignore templates. The title is the name itself
+func ignoreEntries() []listEntry {
+	templates := gitgen.IgnoreTemplates()
+
+	entries := make([]listEntry, len(templates))
+
+	for i, t := range templates {
+		entries[i] = listEntry{t.Name, t.Name, t.Category, t.Source}
+	}
+
+	return entries
+}
+
+// The licenses, with the title and the category of their metadata
+func licenseEntries() []listEntry {
+	templates := gitgen.LicenseTemplates()
+
+	entries := make([]listEntry, len(templates))
+
+	for i, t := range templates {
+		entries[i] = listEntry{Name: t.Name, Title: t.Name, Source: t.Source}
+
+		// Licenses of the user have no metadata
+		if info, err := gitgen.LicenseInfo(t.Name); err == nil && t.Source == gitgen.EmbeddedSource {
+			entries[i].Title = info.Name
+			entries[i].Category = info.Category()
+		}
+	}
+
+	return entries
+}
+
+// Keep the entries whose name or title contains the filter
+func filterEntries(entries []listEntry, filter string) []listEntry {
+	var kept []listEntry
+
+	filter = strings.ToLower(filter)
+
+	for _, e := range entries {
+		if strings.Contains(strings.ToLower(e.Name), filter) ||
+			strings.Contains(strings.ToLower(e.Title), filter) {
+			kept = append(kept, e)
+		}
+	}
+
+	return kept
+}
+
+func entryNames(entries []listEntry) []string {
+	names := make([]string, len(entries))
+
+	for i, e := range entries {
+		names[i] = e.Name
+	}
+
+	return names
+}
+
+// Lay out names in columns that fit in a width, filling
+// them top to bottom like ls does
+func columns(names []string, width int) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	longest := 0
+
+	for _, name := range names {
+		if len(name) > longest {
+			longest = len(name)
+		}
+	}
+
+	// Two spaces between columns
+	colWidth := longest + 2
+
+	cols := width / colWidth
+
+	if cols < 1 {
+		cols = 1
+	}
+
+	rows := (len(names) + cols - 1) / cols
+
+	b := new(strings.Builder)
+
+	for r := 0; r < rows; r++ {
+		line := new(strings.Builder)
+
+		for c := 0; c < cols; c++ {
+			i := c*rows + r
+
+			if i >= len(names) {
+				break
+			}
+
+			fmt.Fprintf(line, "%-*s", colWidth, names[i])
+		}
+
+		b.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+
+	return b.String()
+}
+
+// Tell if the output is a terminal and not a pipe or a file
+func isTerminal(out testableWriter) bool {
+	f, ok := out.(*os.File)
+
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// The width of the terminal, from the COLUMNS variable
+func terminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+
+	return defaultColumns
+}
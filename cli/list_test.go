@@ -0,0 +1,123 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_list(t *testing.T) {
+	tests := []testCase{
+		{
+			"List ignores without extension",
+			[]string{"xd", "ls", "ignore", "go"}, exitOK,
+			"", "Go\nGodot\nIGORPro\n",
+		},
+
+		{
+			"Filter ignoring the case",
+			[]string{"xd", "ls", "i", "PY"}, exitOK,
+			"", "Python\n",
+		},
+
+		{
+			"Filter licenses by title",
+			[]string{"xd", "ls", "license", "affero"}, exitOK,
+			"", "agpl-3.0\n",
+		},
+
+		{
+			"Nothing matches",
+			[]string{"xd", "ls", "license", "wakanda"}, exitOK,
+			"", "",
+		},
+
+		{
+			"Long license list",
+			[]string{"xd", "ls", "--long", "l", "gpl"}, exitOK,
+			"", "agpl-3.0  GNU Affero General Public License v3.0  copyleft  embedded\n" +
+				"gpl-2.0   GNU General Public License v2.0         copyleft  embedded\n" +
+				"gpl-3.0   GNU General Public License v3.0         copyleft  embedded\n" +
+				"lgpl-2.1  GNU Lesser General Public License v2.1  copyleft  embedded\n",
+		},
+
+		{
+			"Long ignore list",
+			[]string{"xd", "ls", "ignore", "godot", "-l"}, exitOK,
+			"", "Godot  Godot  project  embedded\n",
+		},
+
+		{
+			"JSON list",
+			[]string{"xd", "ls", "--json", "license", "mit"}, exitOK,
+			"", `[
+  {
+    "name": "mit",
+    "title": "MIT License",
+    "category": "permissive",
+    "source": "embedded"
+  }
+]
+`,
+		},
+
+		{
+			"Empty JSON list",
+			[]string{"xd", "ls", "--json", "ignore", "wakanda"}, exitOK,
+			"", "[]\n",
+		},
+
+		{
+			"Too many arguments",
+			[]string{"xd", "ls", "ignore", "go", "py"}, exitUsage,
+			"Usage: xd [list|ls] [--long|--json] [ignore|i|license|l] [filter]", "",
+		},
+
+		{
+			"Unknown flag",
+			[]string{"xd", "ls", "--wide", "ignore"}, exitUsage,
+			"Error: flag provided but not defined: -wide", "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+}
+
+func Test_columns(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		width int
+		want  string
+	}{
+		{
+			"Fill top to bottom",
+			[]string{"a", "bb", "ccc", "d", "e"}, 10,
+			"a    d\nbb   e\nccc\n",
+		},
+
+		{
+			"Wider than the terminal",
+			[]string{"Actionscript", "Ada"}, 5,
+			"Actionscript\nAda\n",
+		},
+
+		{
+			"All in one row",
+			[]string{"Go", "C"}, 80,
+			"Go  C\n",
+		},
+
+		{
+			"Nothing", nil, 80, "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := columns(tt.names, tt.width); got != tt.want {
+				t.Errorf("columns() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
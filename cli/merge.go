@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.eduardoandres.dev/gitgen"
+)
+
+// Merge templates into an existing .gitignore, given by -o or the
+// one in the root of the repo. Only missing rules are added, so
+// running it again does nothing
+func mergeIgnore(o outputOptions, keys []string, format string, out testableWriter) error {
+	path := o.target(".gitignore")
+
+	existing, err := os.ReadFile(path)
+
+	// A missing file is the same as an empty one
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	merged := new(bytes.Buffer)
+
+	added, err := gitgen.MergeIgnores(bytes.NewReader(existing), keys, merged)
+
+	if err != nil {
+		return err
+	}
+
+	// Do not touch the file if there is nothing new
+	if added == 0 {
+		return printMerge(path, 0, format, out)
+	}
+
+	// The file is replaced with its old content plus the new rules
+	opts := gitgen.FileOptions{Force: true}
+
+	_, err = gitgen.WriteFileAtomic(path, opts, func(w io.Writer) (int, error) {
+		return w.Write(merged.Bytes())
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return printMerge(path, added, format, out)
+}
+
+// Tell how many rules were added to a file
+func printMerge(path string, added int, format string, out testableWriter) error {
+	if format == formatJSON {
+		return writeJSON(out, struct {
+			Path  string `json:"path"`
+			Added int    `json:"added"`
+		}{path, added})
+	}
+
+	if added == 0 {
+		fmt.Fprintf(out, "%v is up to date\n", path)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Added %d rules to %v\n", added, path)
+
+	return nil
+}
+
+// Add templates in managed blocks to an existing file, given by -o.
+// The blocks already in it are updated in place, so running it
+// again does not repeat them
+func manageIgnore(o outputOptions, keys []string, format string, out testableWriter) error {
+	path := o.target(".gitignore")
+
+	existing, err := os.ReadFile(path)
+
+	// A missing file is the same as an empty one
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	merged := new(bytes.Buffer)
+
+	added, updated, err := gitgen.MergeManagedIgnores(bytes.NewReader(existing), keys, merged)
+
+	if err != nil {
+		return err
+	}
+
+	// Do not touch the file if nothing changed
+	if len(added) != 0 || len(updated) != 0 {
+		opts := gitgen.FileOptions{Force: true}
+
+		_, err = gitgen.WriteFileAtomic(path, opts, func(w io.Writer) (int, error) {
+			return w.Write(merged.Bytes())
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if format == formatJSON {
+		// Always arrays, even if nothing changed
+		if added == nil {
+			added = []string{}
+		}
+
+		if updated == nil {
+			updated = []string{}
+		}
+
+		return writeJSON(out, struct {
+			Path    string   `json:"path"`
+			Added   []string `json:"added"`
+			Updated []string `json:"updated"`
+		}{path, added, updated})
+	}
+
+	if len(added) != 0 {
+		fmt.Fprintf(out, "Added %v to %v\n", strings.Join(added, ", "), path)
+	}
+
+	if len(updated) != 0 {
+		fmt.Fprintf(out, "Updated %v in %v\n", strings.Join(updated, ", "), path)
+	}
+
+	if len(added) == 0 && len(updated) == 0 {
+		fmt.Fprintf(out, "%v is up to date\n", path)
+	}
+
+	return nil
+}
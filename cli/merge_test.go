@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_subcommandMerge(t *testing.T) {
+	dir := t.TempDir()
+	ignore := filepath.Join(dir, ".gitignore")
+
+	os.WriteFile(ignore, []byte("# Ours\n*.o\n"), 0644)
+
+	tests := []testCase{
+		{
+			"Merge Ada into an existing file",
+			[]string{"gg", "i", "--merge", "Ada", "-o", ignore},
+			exitOK, "", "Added 1 rules to " + ignore + "\n",
+		},
+
+		{
+			"Merge again does nothing",
+			[]string{"gg", "i", "Ada", "-m", "-o", ignore},
+			exitOK, "", ignore + " is up to date\n",
+		},
+
+		{
+			"Merge into a new file",
+			[]string{"gg", "i", "--merge", "Yeoman", "-o", filepath.Join(dir, "new")},
+			exitOK, "", "Added 5 rules to " + filepath.Join(dir, "new") + "\n",
+		},
+
+		{
+			"Merge a bad template",
+			[]string{"gg", "i", "--merge", "WakandaForever", "-o", ignore},
+			exitNotFound, "'WakandaForever' gitignore template does not exist", "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+
+	data, _ := os.ReadFile(ignore)
+
+	want := "# Ours\n*.o\n\n### Ada ###\n# Ada Library Information\n*.ali\n"
+
+	if got := string(data); got != want {
+		t.Errorf("The .gitignore has %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"go.eduardoandres.dev/gitgen"
+)
+
+// Where the ignore and license sub commands write to
+type outputOptions struct {
+	// The file to write to. Empty means the output
+	path string
+
+	// Write to the default file in the root of the repo
+	repo bool
+
+	file gitgen.FileOptions
+}
+
+// Register the output flags:
+//
+//	-o, --output FILE  write to a file
+//	-w, --write        write to .gitignore or LICENSE in the repo root
+//	-f, --force        overwrite an existing file
+//	-a, --append       append to an existing file
+func (o *outputOptions) register(fs *flag.FlagSet) {
+	stringFlag(fs, &o.path, "o", "output", "The file to write to")
+	boolFlag(fs, &o.repo, "w", "write", "Write to the default file in the repo")
+	boolFlag(fs, &o.file.Force, "f", "force", "Overwrite an existing file")
+	boolFlag(fs, &o.file.Append, "a", "append", "Append to an existing file")
+}
+
+// What was written to a file, in the JSON format
+type writeResult struct {
+	Action string `json:"action"`
+	Path   string `json:"path"`
+	Bytes  int    `json:"bytes"`
+}
+
+// Write the output of a function to the output or to a file, as the
+// options say. The default name is used with -w. When writing to
+// a file, a message with what was written goes to the output
+func emit(o outputOptions, defaultName string,
+	write func(io.Writer) (int, error), format string, out testableWriter) error {
+
+	if o.path == "" && !o.repo {
+		_, err := write(out)
+		return err
+	}
+
+	r, err := writeOutput(o.target(defaultName), o.file, write)
+
+	if err != nil {
+		return err
+	}
+
+	return printWrites(format, out, r)
+}
+
+// Write the output of a function to a file
+func writeOutput(path string, opts gitgen.FileOptions, write func(io.Writer) (int, error)) (writeResult, error) {
+	n, err := gitgen.WriteFileAtomic(path, opts, write)
+
+	if err != nil {
+		return writeResult{}, err
+	}
+
+	action := "wrote"
+
+	if opts.Append {
+		action = "appended"
+	}
+
+	return writeResult{action, path, n}, nil
+}
+
+// Check that a file can be written with the options, like
+// gitgen.WriteFileAtomic does before writing it
+func checkTarget(path string, opts gitgen.FileOptions) error {
+	_, err := os.Stat(path)
+
+	switch {
+	case err == nil && !opts.Force && !opts.Append:
+		return fmt.Errorf("%s: %w", path, fs.ErrExist)
+
+	case err != nil && !os.IsNotExist(err):
+		return err
+	}
+
+	return nil
+}
+
+// Tell what was written to some files. In the JSON format
+// it is an object for a file and an array for many
+func printWrites(format string, out testableWriter, results ...writeResult) error {
+	if format == formatJSON && len(results) == 1 {
+		return writeJSON(out, results[0])
+	}
+
+	if format == formatJSON {
+		return writeJSON(out, results)
+	}
+
+	for _, r := range results {
+		action := "Wrote"
+
+		if r.Action == "appended" {
+			action = "Appended"
+		}
+
+		fmt.Fprintf(out, "%v %d bytes to %v\n", action, r.Bytes, r.Path)
+	}
+
+	return nil
+}
+
+// Get the file to write to. Without -o it is the default
+// name in the root of the repo, or in the current
+// directory outside one
+func (o outputOptions) target(defaultName string) string {
+	if o.path != "" {
+		return o.path
+	}
+
+	root, err := gitgen.RepoRoot(".")
+
+	if err != nil {
+		root = "."
+	}
+
+	return filepath.Join(root, defaultName)
+}
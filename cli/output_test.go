@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_subcommandOutput(t *testing.T) {
+	dir := t.TempDir()
+	ignore := filepath.Join(dir, ".gitignore")
+	license := filepath.Join(dir, "LICENSE")
+
+	tests := []testCase{
+		{
+			"Write a gitignore",
+			[]string{"gg", "i", "Yeoman", "-o", ignore},
+			exitOK, "",
+			"Wrote 52 bytes to " + ignore + "\n",
+		},
+
+		{
+			"Do not overwrite",
+			[]string{"gg", "i", "Yeoman", "-o", ignore},
+			exitIO,
+			"Error: " + ignore + ": file already exists. Use --force to overwrite it or --append to add to it",
+			"",
+		},
+
+		{
+			"Append",
+			[]string{"gg", "i", "Yeoman", "-o", ignore, "--append"},
+			exitOK, "",
+			"Appended 52 bytes to " + ignore + "\n",
+		},
+
+		{
+			"Write a license with params",
+			[]string{"gg", "lic", "mit", "2021", "Eduardo Castillo", "--output=" + license},
+			exitOK, "",
+			"Wrote 1073 bytes to " + license + "\n",
+		},
+
+		{
+			"Unknown license does not create a file",
+			[]string{"gg", "lic", "lol", "-o", filepath.Join(dir, "NOPE")},
+			exitNotFound, "Error: Unknown license 'lol'", "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+
+	// Check the files
+	data, _ := os.ReadFile(ignore)
+
+	if got := string(data); got != fullYeomanIgnore+fullYeomanIgnore {
+		t.Errorf("The .gitignore has %q", got)
+	}
+
+	data, _ = os.ReadFile(license)
+
+	if got := string(data); got != fullMITWithParams {
+		t.Errorf("The LICENSE has %q", got)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "NOPE")); err == nil {
+		t.Error("A file was created for an unknown license")
+	}
+}
+
+func Test_subcommandOutputRepo(t *testing.T) {
+	root := t.TempDir()
+	os.Mkdir(filepath.Join(root, ".git"), 0755)
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+
+	os.Chdir(root)
+
+	tstOut := new(strings.Builder)
+	tstErr := new(strings.Builder)
+
+	cli([]string{"gg", "i", "Yeoman", "-w"}, tstOut, tstErr)
+
+	if tstErr.Len() != 0 {
+		t.Fatal("Unexpected error: ", tstErr.String())
+	}
+
+	if _, err := os.Stat(filepath.Join(root, ".gitignore")); err != nil {
+		t.Error("The .gitignore was not written in the repo: ", err)
+	}
+
+	// The notice goes to COPYRIGHT
+	cli([]string{"gg", "lic", "gpl-3.0", "--notice", "--project", "gitgen", "-w"}, tstOut, tstErr)
+
+	if tstErr.Len() != 0 {
+		t.Fatal("Unexpected error: ", tstErr.String())
+	}
+
+	data, _ := os.ReadFile(filepath.Join(root, "COPYRIGHT"))
+
+	if !strings.HasPrefix(string(data), "gitgen\nCopyright (C) 2021  Eduardo Castillo\n") {
+		t.Errorf("The COPYRIGHT has %q", data)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.eduardoandres.dev/gitgen"
+)
+
+const updateHelp = `Update managed blocks:
+	Replace the blocks written with gitgen i --managed by the
+	templates of this version of gitgen. Everything outside of
+	the blocks is kept as it is. Works on the .gitignore in the
+	root of the repository, or the file given with -o
+	Examples:
+		gitgen i --managed Node Go -w
+		gitgen update
+		gitgen update -o path/to/.gitignore`
+
+// Run the update sub command. The args start
+// after the sub command itself
+func update(program string, args []string, format string, out testableWriter) error {
+	var o outputOptions
+
+	// Only -o, the file is always rewritten in place
+	fs := newFlagSet("update")
+	stringFlag(fs, &o.path, "o", "output", "The file to update")
+
+	rest, err := parseArgs(fs, args)
+
+	if err == nil && len(rest) != 0 {
+		err = fmt.Errorf("unexpected argument '%v'", rest[0])
+	}
+
+	if err != nil {
+		return newError(codeUsage, "Error: %v. Usage: %v update [-o file]", err, program)
+	}
+
+	path := o.target(".gitignore")
+
+	existing, err := os.ReadFile(path)
+
+	if err != nil {
+		return fileError(err)
+	}
+
+	updated := new(bytes.Buffer)
+
+	names, err := gitgen.UpdateManagedIgnores(bytes.NewReader(existing), updated)
+
+	switch {
+	case errors.Is(err, gitgen.ErrMalformedBlock):
+		return newError(codeInvalid, "Error: %v: %v", path, err)
+
+	case errors.Is(err, gitgen.ErrTemplateNotFound):
+		return newError(codeNotFound, "Error: %v: %v", path, err)
+
+	case err != nil:
+		return fileError(err)
+	}
+
+	// Do not touch the file if nothing changed
+	if len(names) != 0 {
+		opts := gitgen.FileOptions{Force: true}
+
+		_, err = gitgen.WriteFileAtomic(path, opts, func(w io.Writer) (int, error) {
+			return w.Write(updated.Bytes())
+		})
+
+		if err != nil {
+			return fileError(err)
+		}
+	}
+
+	if format == formatJSON {
+		// Always an array, even if nothing changed
+		if names == nil {
+			names = []string{}
+		}
+
+		return writeJSON(out, struct {
+			Path    string   `json:"path"`
+			Updated []string `json:"updated"`
+		}{path, names})
+	}
+
+	if len(names) == 0 {
+		fmt.Fprintf(out, "%v is up to date\n", path)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Updated %v in %v\n", strings.Join(names, ", "), path)
+
+	return nil
+}
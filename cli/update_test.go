@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_subcommandUpdate(t *testing.T) {
+	dir := t.TempDir()
+	ignore := filepath.Join(dir, ".gitignore")
+
+	// A file with an outdated Yeoman block
+	os.WriteFile(ignore, []byte("# Ours\nsecret.txt\n"+
+		"# >>> gitgen Yeoman >>>\n*.old\n# <<< gitgen Yeoman <<<\n"), 0644)
+
+	broken := filepath.Join(dir, "broken")
+	os.WriteFile(broken, []byte("# >>> gitgen Yeoman >>>\n"), 0644)
+
+	tests := []testCase{
+		{
+			"Update the outdated block",
+			[]string{"gg", "update", "-o", ignore},
+			exitOK, "", "Updated Yeoman in " + ignore + "\n",
+		},
+
+		{
+			"Nothing to update",
+			[]string{"gg", "update", "-o", ignore},
+			exitOK, "", ignore + " is up to date\n",
+		},
+
+		{
+			"Block not closed",
+			[]string{"gg", "update", "-o", broken}, exitFailure,
+			"Error: " + broken + ": line 1: block Yeoman is not closed: malformed managed block", "",
+		},
+
+		{
+			"Unexpected argument",
+			[]string{"gg", "update", "Node"}, exitUsage,
+			"Error: unexpected argument 'Node'. Usage: gg update [-o file]", "",
+		},
+
+		{
+			"Write flags",
+			[]string{"gg", "update", "-f"}, exitUsage,
+			"Error: flag provided but not defined: -f. Usage: gg update [-o file]", "",
+		},
+
+		{
+			"Managed and merge",
+			[]string{"gg", "i", "--managed", "--merge", "Node"}, exitUsage,
+			"Error: --merge and --managed can not be used together", "",
+		},
+
+		{
+			"Write managed blocks",
+			[]string{"gg", "i", "--managed", "Yeoman"}, exitOK, "",
+			"# >>> gitgen Yeoman >>>\n" + fullYeomanIgnore + "# <<< gitgen Yeoman <<<\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt.runTest(t)
+	}
+
+	data, _ := os.ReadFile(ignore)
+
+	want := "# Ours\nsecret.txt\n" +
+		"# >>> gitgen Yeoman >>>\n" + fullYeomanIgnore + "# <<< gitgen Yeoman <<<\n"
+
+	if got := string(data); got != want {
+		t.Errorf("The .gitignore has %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+package gitgen
+
+import (
+	"io"
+	"strings"
+)
+
+// CombineIgnores writes several gitignore templates to a writer
+// as a single file. Each template goes under a "### Name ###"
+// header, patterns already written by a previous template are
+// removed and a comment at the top lists the templates used.
+// If any template does not exist nothing is written and the
+// error wraps ErrTemplateNotFound
+func CombineIgnores(keys []string, w io.Writer) (n int, err error) {
+	return defaultGenerator().CombineIgnores(keys, w)
+}
+
+// CombineIgnores writes several gitignore templates of
+// the source to a writer as a single file
+func (g *Generator) CombineIgnores(keys []string, w io.Writer) (n int, err error) {
+	names, texts, err := g.loadIgnores(keys)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return io.WriteString(w, combine(names, texts))
+}
+
+// Get the names and the texts of several gitignore templates.
+// Keys that resolve to the same template are used once
+func (g *Generator) loadIgnores(keys []string) (names, texts []string, err error) {
+	seen := make(map[string]bool)
+
+	for _, key := range keys {
+		name := g.resolveIgnore(key)
+
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+
+		txt, err := g.IgnoreText(key)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		names = append(names, name)
+		texts = append(texts, txt)
+	}
+
+	return names, texts, nil
+}
+
+// Join the templates, removing duplicated patterns
+func combine(names, texts []string) string {
+	b := new(strings.Builder)
+
+	b.WriteString("# Generated by gitgen from: " + strings.Join(names, ", ") + "\n")
+
+	// Patterns written so far
+	patterns := make(map[string]bool)
+
+	for i, name := range names {
+		b.WriteString("\n### " + name + " ###\n")
+
+		for _, line := range parseIgnoreBytes([]byte(texts[i])).Lines {
+			// Comments and blank lines are kept as they are
+			if line.Kind == LinePattern {
+				key := patternKey(line.Text)
+
+				if patterns[key] {
+					continue
+				}
+
+				patterns[key] = true
+			}
+
+			b.WriteString(line.Text + lineEnding(line))
+		}
+	}
+
+	return b.String()
+}
+
+// The line ending of a line of a template. The last
+// line ends in a newline even if the template does not
+func lineEnding(line Line) string {
+	if line.Ending == "" {
+		return "\n"
+	}
+
+	return line.Ending
+}
+
+// The text used to compare two patterns. Trailing spaces
+// are not part of a pattern
+func patternKey(line string) string {
+	return trimTrailingSpaces(strings.TrimRight(line, "\r"))
+}
@@ -0,0 +1,97 @@
+package gitgen
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCombineIgnores(t *testing.T) {
+	w := new(bytes.Buffer)
+
+	// Both Ada and C ignore *.o
+	if _, err := CombineIgnores([]string{"Ada", "C", "Ada"}, w); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	got := w.String()
+
+	t.Run("Header lists the templates once", func(t *testing.T) {
+		want := "# Generated by gitgen from: Ada, C\n"
+
+		if !strings.HasPrefix(got, want) {
+			t.Errorf("CombineIgnores() should start with '%v'", want)
+		}
+	})
+
+	t.Run("Every template has a section", func(t *testing.T) {
+		for _, section := range []string{"### Ada ###", "### C ###"} {
+			if strings.Count(got, section) != 1 {
+				t.Errorf("Expected '%v' exactly once", section)
+			}
+		}
+	})
+
+	t.Run("Duplicated patterns are removed", func(t *testing.T) {
+		if n := strings.Count(got, "\n*.o\n"); n != 1 {
+			t.Errorf("Expected *.o once, got it %d times", n)
+		}
+	})
+
+	t.Run("Comments are kept", func(t *testing.T) {
+		// Both templates start with a similar comment
+		if n := strings.Count(got, "# Object file"); n != 2 {
+			t.Errorf("Expected the object file comments, got them %d times", n)
+		}
+	})
+}
+
+func TestCombineIgnores_badKey(t *testing.T) {
+	w := new(bytes.Buffer)
+
+	_, err := CombineIgnores([]string{"Ada", "WakandaForever"}, w)
+
+	if !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+	}
+
+	// Nothing should be written
+	if w.Len() != 0 {
+		t.Errorf("Expected no output, got '%v'", w.String())
+	}
+}
+
+func Test_combine(t *testing.T) {
+	got := combine(
+		[]string{"One", "Two"},
+		[]string{"# Build\nbin/\n\n*.log\n", "# Logs\n*.log  \nout/\n"},
+	)
+
+	want := `# Generated by gitgen from: One, Two
+
+### One ###
+# Build
+bin/
+
+*.log
+
+### Two ###
+# Logs
+out/
+`
+
+	if got != want {
+		t.Errorf("combine() = '%v', want '%v'", got, want)
+	}
+}
+
+func Test_combine_lineEndings(t *testing.T) {
+	got := combine([]string{"macOS"}, []string{"# Icon\nIcon\r\r\n\r\n*.tmp"})
+
+	want := "# Generated by gitgen from: macOS\n\n### macOS ###\n# Icon\nIcon\r\r\n\r\n*.tmp\n"
+
+	if got != want {
+		t.Errorf("combine() = %q, want %q", got, want)
+	}
+}
@@ -1,23 +1,135 @@
 package gitgen
 
-import "embed"
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
 
 //go:embed assets
 var assets embed.FS
 
-func asset(name string) ([]byte, error) {
-	return assets.ReadFile("assets/" + name)
+// EmbeddedSource is the source of the templates that
+// come with the package
+const EmbeddedSource = "embedded"
+
+// The categories of the gitignore templates. Global templates
+// are for editors and operating systems, and belong in the
+// excludes file of the user instead of the repository
+const (
+	CategoryProject = "project"
+	CategoryGlobal  = "global"
+)
+
+// The folders of the project and the global gitignore templates
+const (
+	ignoresFolder = "ignores"
+	globalFolder  = "ignores/Global"
+)
+
+// Template is an available template
+type Template struct {
+	// Name is the key of the template
+	Name string `json:"name"`
+
+	// Source is EmbeddedSource, or the name of the
+	// set of templates of the user it comes from
+	Source string `json:"source"`
+
+	// Category is CategoryProject or CategoryGlobal for gitignore
+	// templates. It is empty for licenses, see License.Category
+	Category string `json:"category,omitempty"`
 }
 
-// Return the contents of an embeded folder
-func listAssets(folder string) []string {
-	entries, _ := assets.ReadDir("assets/" + folder)
+// The environment variable with the template directories
+// of the user, separated like PATH
+const templatePathEnv = "GITGEN_TEMPLATE_PATH"
 
-	names := make([]string, len(entries))
+// The templates of the user, searched in order
+// before the embeded assets
+var (
+	userLayers   []Source
+	userLayersMu sync.RWMutex
+)
 
-	for i, val := range entries {
-		names[i] = val.Name()
+// AddTemplateFS adds a set of templates that is searched before
+// the embeded ones and the ones added before it. It has the
+// same layout as the embeded assets: gitignore templates in
+// ignores/Name.gitignore, global ones in
+// ignores/Global/Name.gitignore and licenses in licenses/key.txt. A
+// template with the same name as an embeded one replaces it.
+// The name is shown as the source of its templates
+func AddTemplateFS(name string, fsys fs.FS) {
+	AddTemplateSource(&FSSource{name, fsys})
+}
+
+// AddTemplateSource is like AddTemplateFS, but with any Source.
+// It is safe to call while the templates are used, but a call
+// only takes effect on the functions called after it. Services
+// that need their own templates should use NewGenerator instead
+func AddTemplateSource(source Source) {
+	userLayersMu.Lock()
+	defer userLayersMu.Unlock()
+
+	userLayers = append([]Source{source}, userLayers...)
+}
+
+// AddTemplateDirs adds template directories from disk like
+// AddTemplateFS. The first directory has the highest priority.
+// Directories that do not exist are skipped
+func AddTemplateDirs(dirs ...string) {
+	// Add the last one first, so the first one ends on top
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if info, err := os.Stat(dirs[i]); err != nil || !info.IsDir() {
+			continue
+		}
+
+		AddTemplateFS(dirs[i], os.DirFS(dirs[i]))
 	}
+}
+
+// UserTemplateDirs returns the template directories of the user:
+// the ones in GITGEN_TEMPLATE_PATH, separated like PATH, or
+// gitgen/templates in the XDG config folder, which defaults to
+// ~/.config/gitgen/templates
+func UserTemplateDirs() []string {
+	if env := os.Getenv(templatePathEnv); env != "" {
+		var dirs []string
 
-	return names
+		for _, dir := range filepath.SplitList(env) {
+			if dir != "" {
+				dirs = append(dirs, dir)
+			}
+		}
+
+		return dirs
+	}
+
+	config := os.Getenv("XDG_CONFIG_HOME")
+
+	if config == "" {
+		home, err := os.UserHomeDir()
+
+		if err != nil {
+			return nil
+		}
+
+		config = filepath.Join(home, ".config")
+	}
+
+	return []string{filepath.Join(config, "gitgen", "templates")}
+}
+
+// Read a template from the layers of the user
+// or, if none has it, from the embeded assets
+func asset(name string) ([]byte, error) {
+	return defaultGenerator().asset(name)
+}
+
+// Return the contents of a folder in all the layers
+// and the embeded assets, without repeating names
+func listAssets(folder string) []string {
+	return defaultGenerator().listAssets(folder)
 }
@@ -1,7 +1,13 @@
 package gitgen
 
 import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
 	"testing"
+	"testing/fstest"
 )
 
 func Test_asset(t *testing.T) {
@@ -37,3 +43,190 @@ func Test_asset(t *testing.T) {
 		})
 	}
 }
+
+// Add a set of templates for the duration of a test
+func withTemplates(t *testing.T, name string, fsys fs.FS) {
+	old := userLayers
+
+	t.Cleanup(func() { userLayers = old })
+
+	AddTemplateFS(name, fsys)
+}
+
+// Templates of a company, replacing the embeded Go template
+var companyTemplates = fstest.MapFS{
+	"ignores/Go.gitignore":      {Data: []byte("# Our Go\nbin/\n")},
+	"ignores/Bazel.gitignore":   {Data: []byte("bazel-*\n")},
+	"ignores/README.md":         {Data: []byte("Not a template")},
+	"licenses/acme.txt":         {Data: []byte("Copyright [year] [fullname]\n")},
+	"licenses/nested/other.txt": {Data: []byte("Not listed")},
+}
+
+func TestAddTemplateFS(t *testing.T) {
+	withTemplates(t, "company", companyTemplates)
+
+	tests := []struct {
+		name, key, want string
+	}{
+		{"Replace an embeded template", "Go", "# Our Go\nbin/\n"},
+		{"New template", "bazel", "bazel-*\n"},
+		{"Embeded template", "Yeoman", "node_modules/\nbower_components/\n*.log\n\nbuild/\ndist/\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IgnoreText(tt.key)
+
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("IgnoreText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := GetLicWithParams("acme", "eacp", "2021"); got != "Copyright 2021 eacp\n" {
+		t.Errorf("GetLicWithParams() = %q", got)
+	}
+
+	// The embeded ones plus Bazel
+	if got := len(ListIgnores()); got != 135 {
+		t.Errorf("Expected 135 gitignore templates, got %d", got)
+	}
+}
+
+func TestAddTemplateFS_Priority(t *testing.T) {
+	withTemplates(t, "first", companyTemplates)
+	withTemplates(t, "second", fstest.MapFS{
+		"ignores/Go.gitignore": {Data: []byte("# Second\n")},
+	})
+
+	// The last one added wins
+	if got := GetIgnoreText("Go"); got != "# Second\n" {
+		t.Errorf("GetIgnoreText() = %q", got)
+	}
+}
+
+func TestIgnoreTemplates(t *testing.T) {
+	withTemplates(t, "company", companyTemplates)
+
+	sources := make(map[string]string)
+
+	for _, tmpl := range IgnoreTemplates() {
+		sources[tmpl.Name] = tmpl.Source
+	}
+
+	want := map[string]string{
+		"Go":     "company",
+		"Bazel":  "company",
+		"Yeoman": EmbeddedSource,
+	}
+
+	for name, source := range want {
+		if got := sources[name]; got != source {
+			t.Errorf("Source of %v = %v, want %v", name, got, source)
+		}
+	}
+
+	if _, ok := sources["README.md"]; ok {
+		t.Error("Files that are not templates should not be listed")
+	}
+
+	licenses := LicenseTemplates()
+
+	if got := len(licenses); got != 14 {
+		t.Errorf("Expected 14 licenses, got %d", got)
+	}
+
+	if licenses[0] != (Template{Name: "acme", Source: "company"}) {
+		t.Errorf("Unexpected first license %v", licenses[0])
+	}
+}
+
+func TestAddTemplateDirs(t *testing.T) {
+	old := userLayers
+	defer func() { userLayers = old }()
+
+	first, second := t.TempDir(), t.TempDir()
+
+	for _, dir := range []string{first, second} {
+		os.Mkdir(filepath.Join(dir, "ignores"), 0755)
+		os.WriteFile(filepath.Join(dir, "ignores", "Go.gitignore"), []byte(dir), 0644)
+	}
+
+	AddTemplateDirs(first, filepath.Join(first, "missing"), second)
+
+	if got := len(userLayers); got != 2 {
+		t.Fatalf("Expected 2 layers, got %d", got)
+	}
+
+	// The first directory wins
+	if got := GetIgnoreText("Go"); got != first {
+		t.Errorf("GetIgnoreText() = %v, want %v", got, first)
+	}
+
+	if got := IgnoreTemplates(); !containsTemplate(got, Template{"Go", first, CategoryProject}) {
+		t.Errorf("Go should come from %v", first)
+	}
+}
+
+func TestAddTemplateSource_concurrent(t *testing.T) {
+	old := userLayers
+	defer func() { userLayers = old }()
+
+	fsys := fstest.MapFS{"ignores/Acme.gitignore": {Data: []byte("acme/\n")}}
+
+	var wg sync.WaitGroup
+
+	// Run with -race to check the layers are guarded
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			AddTemplateFS("company", fsys)
+		}()
+
+		go func() {
+			defer wg.Done()
+			GetIgnoreText("Go")
+		}()
+	}
+
+	wg.Wait()
+
+	if got := GetIgnoreText("Acme"); got != "acme/\n" {
+		t.Errorf("GetIgnoreText() = %q", got)
+	}
+}
+
+func containsTemplate(list []Template, want Template) bool {
+	for _, tmpl := range list {
+		if tmpl == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestUserTemplateDirs(t *testing.T) {
+	sep := string(os.PathListSeparator)
+
+	defer setEnv("GITGEN_TEMPLATE_PATH", "a"+sep+sep+"b")()
+
+	if got := UserTemplateDirs(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("UserTemplateDirs() = %v", got)
+	}
+
+	defer setEnv("GITGEN_TEMPLATE_PATH", "")()
+	defer setEnv("XDG_CONFIG_HOME", "xdg")()
+
+	want := []string{filepath.Join("xdg", "gitgen", "templates")}
+
+	if got := UserTemplateDirs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("UserTemplateDirs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+package gitgen
+
+import (
+	"strconv"
+	"time"
+)
+
+// Defaults fills the year and the name of a license
+// when they are not given
+type Defaults struct {
+	// Now returns the current time. If it is nil time.Now is used
+	Now func() time.Time
+
+	// Config is where user.name comes from. If it is nil
+	// there is no default name
+	Config ConfigSource
+}
+
+// SystemDefaults uses the clock of the system and the
+// git config of a directory, see UserGitConfig
+func SystemDefaults(dir string) Defaults {
+	return Defaults{
+		Now:    time.Now,
+		Config: UserGitConfig(dir),
+	}
+}
+
+// Year returns the current year
+func (d Defaults) Year() string {
+	now := d.Now
+
+	if now == nil {
+		now = time.Now
+	}
+
+	return strconv.Itoa(now().Year())
+}
+
+// Name returns user.name from the git config, or an empty string
+func (d Defaults) Name() string {
+	if d.Config == nil {
+		return ""
+	}
+
+	name, _ := d.Config.Get("user.name")
+
+	return name
+}
+
+// Apply returns the name and the year, using the
+// defaults for the ones that are empty
+func (d Defaults) Apply(fullname, year string) (string, string) {
+	if fullname == "" {
+		fullname = d.Name()
+	}
+
+	if year == "" {
+		year = d.Year()
+	}
+
+	return fullname, year
+}
+
+// ApplyParams returns the parameters of a license with the
+// default year and holder if they are not given. The holder
+// is only used if there are no Holders and no Organization
+func (d Defaults) ApplyParams(p LicenseParams) LicenseParams {
+	if len(p.Holders) == 0 && p.Organization == "" {
+		if name := d.Name(); name != "" {
+			p.Holders = []string{name}
+		}
+	}
+
+	if p.Year == "" {
+		p.Year = d.Year()
+	}
+
+	return p
+}
@@ -0,0 +1,89 @@
+package gitgen
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDefaults(t *testing.T) {
+	d := Defaults{
+		Now: func() time.Time {
+			return time.Date(2021, 3, 14, 0, 0, 0, 0, time.UTC)
+		},
+		Config: GitConfig{"user.name": "eacp"},
+	}
+
+	tests := []struct {
+		name, fullname, year string
+		wantName, wantYear   string
+	}{
+		{"Both missing", "", "", "eacp", "2021"},
+		{"Name given", "Ada", "", "Ada", "2021"},
+		{"Year given", "", "1815", "eacp", "1815"},
+		{"Both given", "Ada", "1815", "Ada", "1815"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, year := d.Apply(tt.fullname, tt.year)
+
+			if name != tt.wantName || year != tt.wantYear {
+				t.Errorf("Apply() = %v, %v, want %v, %v", name, year, tt.wantName, tt.wantYear)
+			}
+		})
+	}
+}
+
+func TestDefaults_zero(t *testing.T) {
+	var d Defaults
+
+	if got := d.Name(); got != "" {
+		t.Errorf("Name() = %v, want an empty string", got)
+	}
+
+	if got, want := d.Year(), time.Now().Format("2006"); got != want {
+		t.Errorf("Year() = %v, want %v", got, want)
+	}
+}
+
+func TestDefaults_ApplyParams(t *testing.T) {
+	d := Defaults{
+		Now: func() time.Time {
+			return time.Date(2021, 3, 14, 0, 0, 0, 0, time.UTC)
+		},
+		Config: GitConfig{"user.name": "eacp"},
+	}
+
+	tests := []struct {
+		name   string
+		params LicenseParams
+		want   LicenseParams
+	}{
+		{
+			"Empty",
+			LicenseParams{},
+			LicenseParams{Holders: []string{"eacp"}, Year: "2021"},
+		},
+
+		{
+			"Given",
+			LicenseParams{Holders: []string{"Ana"}, Year: "2019-2020"},
+			LicenseParams{Holders: []string{"Ana"}, Year: "2019-2020"},
+		},
+
+		{
+			"The organization is the holder",
+			LicenseParams{Organization: "Acme"},
+			LicenseParams{Organization: "Acme", Year: "2021"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.ApplyParams(tt.params); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package gitgen
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Detection is a gitignore template that applies to a project
+type Detection struct {
+	// Template is the name of the gitignore template
+	Template string `json:"template"`
+
+	// Marker is the first file that revealed the template,
+	// relative to the scanned directory
+	Marker string `json:"marker"`
+}
+
+// A file name pattern and the templates it reveals
+type marker struct {
+	pattern   string
+	templates []string
+}
+
+// Files that reveal the stack of a project. The patterns are
+// matched against the file names with path.Match
+var markers = []marker{
+	{"go.mod", []string{"Go"}},
+	{"package.json", []string{"Node"}},
+	{"Cargo.toml", []string{"Rust"}},
+	{"pom.xml", []string{"Maven", "Java"}},
+	{"build.gradle", []string{"Gradle", "Java"}},
+	{"build.gradle.kts", []string{"Gradle", "Kotlin"}},
+	{"*.csproj", []string{"VisualStudio"}},
+	{"*.fsproj", []string{"VisualStudio"}},
+	{"*.vbproj", []string{"VisualStudio"}},
+	{"*.sln", []string{"VisualStudio"}},
+	{"pubspec.yaml", []string{"Dart"}},
+	{"CMakeLists.txt", []string{"CMake"}},
+	{"requirements.txt", []string{"Python"}},
+	{"setup.py", []string{"Python"}},
+	{"pyproject.toml", []string{"Python"}},
+	{"Pipfile", []string{"Python"}},
+	{"Gemfile", []string{"Ruby"}},
+	{"composer.json", []string{"Composer"}},
+	{"artisan", []string{"Laravel"}},
+	{"mix.exs", []string{"Elixir"}},
+	{"rebar.config", []string{"Erlang"}},
+	{"stack.yaml", []string{"Haskell"}},
+	{"*.cabal", []string{"Haskell"}},
+	{"project.clj", []string{"Leiningen"}},
+	{"elm.json", []string{"Elm"}},
+	{"dune-project", []string{"OCaml"}},
+	{"*.nimble", []string{"Nim"}},
+	{"Project.toml", []string{"Julia"}},
+	{"Package.swift", []string{"Swift"}},
+	{"*.tf", []string{"Terraform"}},
+	{"*.Rproj", []string{"R"}},
+	{"configure.ac", []string{"Autotools"}},
+	{"SConstruct", []string{"SCons"}},
+	{"project.godot", []string{"Godot"}},
+	{"*.uproject", []string{"UnrealEngine"}},
+	{"_config.yml", []string{"Jekyll"}},
+	{"wp-config.php", []string{"WordPress"}},
+	{"*.tex", []string{"TeX"}},
+	{"*.c", []string{"C"}},
+	{"*.cpp", []string{"C++"}},
+	{"*.cc", []string{"C++"}},
+	{"*.hpp", []string{"C++"}},
+}
+
+// How deep in the tree markers are searched. The root is 0
+const maxDetectDepth = 3
+
+// Folders that never contain project markers, or contain
+// the markers of dependencies
+var skipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"target":       true,
+	"build":        true,
+	"dist":         true,
+}
+
+// DetectIgnores walks a directory and returns the gitignore
+// templates that apply to it, sorted by name
+func DetectIgnores(dir string) ([]Detection, error) {
+	return DetectIgnoresFS(os.DirFS(dir))
+}
+
+// DetectIgnoresFS is like DetectIgnores but for any fs.FS
+func DetectIgnoresFS(fsys fs.FS) ([]Detection, error) {
+	found := make(map[string]string)
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if name == "." {
+				return nil
+			}
+
+			// Skip hidden folders, dependencies and deep folders
+			base := d.Name()
+
+			if strings.HasPrefix(base, ".") || skipDirs[base] ||
+				strings.Count(name, "/") >= maxDetectDepth {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		for _, m := range markers {
+			if ok, _ := path.Match(m.pattern, d.Name()); !ok {
+				continue
+			}
+
+			// Keep the first marker of each template
+			for _, template := range m.templates {
+				if _, seen := found[template]; !seen {
+					found[template] = name
+				}
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	detections := make([]Detection, 0, len(found))
+
+	for template, name := range found {
+		detections = append(detections, Detection{template, name})
+	}
+
+	sort.Slice(detections, func(i, j int) bool {
+		return detections[i].Template < detections[j].Template
+	})
+
+	return detections, nil
+}
@@ -0,0 +1,58 @@
+package gitgen
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDetectIgnoresFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":                     {},
+		"web/package.json":           {},
+		"web/node_modules/x/go.mod":  {}, // Dependencies are skipped
+		"api/App/App.csproj":         {},
+		"native/CMakeLists.txt":      {},
+		".github/workflows/setup.py": {}, // Hidden folders are skipped
+		"a/b/c/d/Cargo.toml":         {}, // Too deep
+		"server/pom.xml":             {},
+	}
+
+	got, err := DetectIgnoresFS(fsys)
+
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	want := []Detection{
+		{"CMake", "native/CMakeLists.txt"},
+		{"Go", "go.mod"},
+		{"Java", "server/pom.xml"},
+		{"Maven", "server/pom.xml"},
+		{"Node", "web/package.json"},
+		{"VisualStudio", "api/App/App.csproj"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectIgnoresFS() = %v, want %v", got, want)
+	}
+}
+
+func TestDetectIgnoresFS_empty(t *testing.T) {
+	got, err := DetectIgnoresFS(fstest.MapFS{"README.md": {}})
+
+	if err != nil || len(got) != 0 {
+		t.Errorf("DetectIgnoresFS() = %v, %v, want nothing", got, err)
+	}
+}
+
+// Every marker must point to an existing template
+func Test_markerTemplates(t *testing.T) {
+	for _, m := range markers {
+		for _, template := range m.templates {
+			if _, err := IgnoreText(template); err != nil {
+				t.Errorf("Marker %v: %v", m.pattern, err)
+			}
+		}
+	}
+}
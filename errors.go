@@ -0,0 +1,97 @@
+package gitgen
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// ErrTemplateNotFound is the sentinel error for a template
+// that does not exist. Use errors.Is to check for it
+var ErrTemplateNotFound = errors.New("template not found")
+
+// The kinds of templates the package knows about
+const (
+	kindIgnore  = "gitignore"
+	kindLicense = "license"
+)
+
+// NotFoundError is returned when a gitignore or license template
+// could not be found. It contains the requested key and a list of
+// templates with a similar name. It wraps ErrTemplateNotFound
+type NotFoundError struct {
+	// Kind is either "gitignore" or "license"
+	Kind string
+
+	// Key is the requested template
+	Key string
+
+	// Suggestions contains the names of close matches, if any
+	Suggestions []string
+}
+
+func (e *NotFoundError) Error() string {
+	msg := fmt.Sprintf("%s template '%s' not found", e.Kind, e.Key)
+
+	if len(e.Suggestions) != 0 {
+		msg += ", did you mean " + strings.Join(e.Suggestions, ", ") + "?"
+	}
+
+	return msg
+}
+
+// Unwrap allows errors.Is(err, ErrTemplateNotFound)
+func (e *NotFoundError) Unwrap() error {
+	return ErrTemplateNotFound
+}
+
+// Create a NotFoundError for a missing key, looking for close matches
+// in the list of available templates
+func notFound(kind, key string, available []string) error {
+	return &NotFoundError{
+		Kind:        kind,
+		Key:         key,
+		Suggestions: suggest(key, available, maxSuggestions),
+	}
+}
+
+// Translate a missing embeded file into a NotFoundError. Other errors
+// are returned as they are. The available names have no extension
+func wrapAssetErr(err error, kind, key string, available func() []string) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return notFound(kind, key, available())
+	}
+
+	return err
+}
+
+// ErrCopyrightInNotice is returned when a name or a year are given
+// for a license written verbatim, like the GPL. Its copyright goes
+// in its notice instead, see Notice
+var ErrCopyrightInNotice = errors.New("the copyright goes in the notice of the license, see Notice")
+
+// ErrUnfilledPlaceholders is the sentinel error for a license
+// rendered in strict mode with placeholders left unfilled
+var ErrUnfilledPlaceholders = errors.New("unfilled placeholders")
+
+// PlaceholderError is returned by RenderLicenseStrict when some
+// placeholders of a license have no value. It wraps
+// ErrUnfilledPlaceholders
+type PlaceholderError struct {
+	// Key is the license
+	Key string
+
+	// Placeholders are the ones left, in the order they appear
+	Placeholders []string
+}
+
+func (e *PlaceholderError) Error() string {
+	return fmt.Sprintf("license '%s' has unfilled placeholders: %s",
+		e.Key, strings.Join(e.Placeholders, ", "))
+}
+
+// Unwrap allows errors.Is(err, ErrUnfilledPlaceholders)
+func (e *PlaceholderError) Unwrap() error {
+	return ErrUnfilledPlaceholders
+}
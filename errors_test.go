@@ -0,0 +1,76 @@
+package gitgen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNotFoundError(t *testing.T) {
+	tests := []struct {
+		name, kind, key string
+		err             error
+	}{
+		{"Missing gitignore", kindIgnore, "WakandaForever", ignoreErr("WakandaForever")},
+		{"Missing license", kindLicense, "lol", licenseErr("lol")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// It should be detectable with errors.Is
+			if !errors.Is(tt.err, ErrTemplateNotFound) {
+				t.Fatalf("errors.Is(%v, ErrTemplateNotFound) = false", tt.err)
+			}
+
+			// And with errors.As
+			var nf *NotFoundError
+
+			if !errors.As(tt.err, &nf) {
+				t.Fatalf("errors.As(%v, *NotFoundError) = false", tt.err)
+			}
+
+			if nf.Kind != tt.kind || nf.Key != tt.key {
+				t.Errorf("Got kind '%v' and key '%v', want '%v' and '%v'",
+					nf.Kind, nf.Key, tt.kind, tt.key)
+			}
+		})
+	}
+}
+
+func TestNotFoundError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *NotFoundError
+		want string
+	}{
+		{
+			"Without suggestions",
+			&NotFoundError{kindIgnore, "lol", nil},
+			"gitignore template 'lol' not found",
+		},
+		{
+			"With suggestions",
+			&NotFoundError{kindLicense, "gpl", []string{"agpl-3.0", "gpl-2.0"}},
+			"license template 'gpl' not found, did you mean agpl-3.0, gpl-2.0?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = '%v', want '%v'", got, tt.want)
+			}
+		})
+	}
+}
+
+// Helpers to get the errors of the public API
+
+func ignoreErr(key string) error {
+	_, err := IgnoreText(key)
+	return err
+}
+
+func licenseErr(key string) error {
+	_, err := LicenseText(key)
+	return err
+}
@@ -0,0 +1,61 @@
+package gitgen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GlobalExcludesFile returns the global gitignore of the user, where
+// the templates of editors and operating systems belong. It is the
+// core.excludesFile of the config or, if it is not set, the default
+// of git: $XDG_CONFIG_HOME/git/ignore, or ~/.config/git/ignore. A ~
+// at the start of the path is the home of the user
+func GlobalExcludesFile(config ConfigSource) (string, error) {
+	if config != nil {
+		if name, ok := config.Get("core.excludesFile"); ok && name != "" {
+			return expandHome(name)
+		}
+	}
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "git", "ignore"), nil
+	}
+
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", "git", "ignore"), nil
+}
+
+// LocalExcludesFile returns the .git/info/exclude of the repository
+// that contains a directory. Its patterns only apply to the local
+// clone and are never committed. If there is no repository, the
+// error wraps fs.ErrNotExist
+func LocalExcludesFile(dir string) (string, error) {
+	root, err := RepoRoot(dir)
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(gitDir(root), "info", "exclude"), nil
+}
+
+// Replace a ~ at the start of a path with the home of the user
+func expandHome(name string) (string, error) {
+	if name != "~" && !strings.HasPrefix(name, "~/") {
+		return name, nil
+	}
+
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, name[1:]), nil
+}
@@ -0,0 +1,92 @@
+package gitgen
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGlobalExcludesFile(t *testing.T) {
+	home, _ := os.UserHomeDir()
+
+	defer setEnv("XDG_CONFIG_HOME", "/xdg")()
+
+	tests := []struct {
+		name   string
+		config ConfigSource
+		want   string
+	}{
+		{"Configured", GitConfig{"core.excludesfile": "/etc/gitignore"}, "/etc/gitignore"},
+		{"In the home", GitConfig{"core.excludesfile": "~/.gitignore_global"}, filepath.Join(home, ".gitignore_global")},
+		{"Default", GitConfig{}, filepath.Join("/xdg", "git", "ignore")},
+		{"No config", nil, filepath.Join("/xdg", "git", "ignore")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GlobalExcludesFile(tt.config)
+
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("GlobalExcludesFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	defer setEnv("XDG_CONFIG_HOME", "")()
+
+	want := filepath.Join(home, ".config", "git", "ignore")
+
+	if got, _ := GlobalExcludesFile(nil); got != want {
+		t.Errorf("GlobalExcludesFile() = %v, want %v", got, want)
+	}
+}
+
+func TestLocalExcludesFile(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+
+	os.Mkdir(filepath.Join(root, ".git"), 0755)
+	os.Mkdir(sub, 0755)
+
+	got, err := LocalExcludesFile(sub)
+
+	if want := filepath.Join(root, ".git", "info", "exclude"); err != nil || got != want {
+		t.Errorf("LocalExcludesFile() = %v, %v, want %v", got, err, want)
+	}
+
+	if _, err := LocalExcludesFile(t.TempDir()); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("LocalExcludesFile() error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestGlobalTemplates(t *testing.T) {
+	global := map[string]bool{
+		"macOS": true, "Windows": true, "Linux": true, "JetBrains": true,
+		"VisualStudioCode": true, "Vim": true, "Emacs": true,
+	}
+
+	for _, tmpl := range IgnoreTemplates() {
+		want := CategoryProject
+
+		if global[tmpl.Name] {
+			want = CategoryGlobal
+		}
+
+		if tmpl.Category != want {
+			t.Errorf("Category of %v = %v, want %v", tmpl.Name, tmpl.Category, want)
+		}
+	}
+
+	// Aliases and names in any case
+	for _, key := range []string{"osx", "MACOS", "vscode", "idea", "nvim"} {
+		if _, err := IgnoreText(key); err != nil {
+			t.Errorf("IgnoreText(%v) error = %v", key, err)
+		}
+	}
+}
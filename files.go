@@ -0,0 +1,172 @@
+package gitgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileOptions controls what happens when writing
+// to a file that already exists
+type FileOptions struct {
+	// Force overwrites an existing file
+	Force bool
+
+	// Append adds to the end of an existing file
+	Append bool
+}
+
+// WriteIgnoreFile writes one or more gitignore templates to a
+// file, combining them like CombineIgnores if there are many.
+// An existing file is not touched unless opts allow it, and the
+// error wraps fs.ErrExist. The file is written to a temporary
+// file first and then renamed, so it is never left half written.
+// It returns the number of bytes of the templates written
+func WriteIgnoreFile(name string, keys []string, opts FileOptions) (int, error) {
+	return defaultGenerator().WriteIgnoreFile(name, keys, opts)
+}
+
+// WriteIgnoreFile writes gitignore templates of the source to a file
+func (g *Generator) WriteIgnoreFile(name string, keys []string, opts FileOptions) (int, error) {
+	return WriteFileAtomic(name, opts, func(w io.Writer) (int, error) {
+		if len(keys) == 1 {
+			return g.WriteIgnore(keys[0], w)
+		}
+
+		return g.CombineIgnores(keys, w)
+	})
+}
+
+// WriteLicenseFile is like WriteIgnoreFile, but for a license. If
+// fullname or year are not empty they are filled in the license
+// like WriteLicWithParams does, so the licenses with their own
+// notice, like the GPL, fail with ErrCopyrightInNotice and the
+// file is not written
+func WriteLicenseFile(name, key, fullname, year string, opts FileOptions) (int, error) {
+	return defaultGenerator().WriteLicenseFile(name, key, fullname, year, opts)
+}
+
+// WriteLicenseFile writes a license of the source to a file
+func (g *Generator) WriteLicenseFile(name, key, fullname, year string, opts FileOptions) (int, error) {
+	return WriteFileAtomic(name, opts, func(w io.Writer) (int, error) {
+		if fullname == "" && year == "" {
+			return g.WriteLicense(key, w)
+		}
+
+		return g.WriteLicWithParams(key, fullname, year, w)
+	})
+}
+
+// WriteFileAtomic writes the output of a function to a file,
+// following the same rules as WriteIgnoreFile. Nothing is
+// written to disk if the function fails
+func WriteFileAtomic(name string, opts FileOptions,
+	write func(io.Writer) (int, error)) (int, error) {
+
+	// Generate everything first
+	content := new(bytes.Buffer)
+
+	n, err := write(content)
+
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := os.Stat(name)
+
+	exists := err == nil
+
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+
+	if exists && !opts.Force && !opts.Append {
+		return 0, fmt.Errorf("%s: %w", name, fs.ErrExist)
+	}
+
+	var data []byte
+
+	// Keep the old content when appending
+	if exists && opts.Append {
+		if data, err = os.ReadFile(name); err != nil {
+			return 0, err
+		}
+
+		// Do not glue the first new line to the last old one
+		if len(data) != 0 && data[len(data)-1] != '\n' {
+			data = append(data, '\n')
+		}
+	}
+
+	data = append(data, content.Bytes()...)
+
+	mode := fs.FileMode(0644)
+
+	if exists {
+		mode = info.Mode().Perm()
+	}
+
+	if err := replaceFile(name, data, mode); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// Write to a temporary file in the same folder
+// and rename it to the final name
+func replaceFile(name string, data []byte, mode fs.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp*")
+
+	if err != nil {
+		return err
+	}
+
+	// Clean up if anything fails. After the
+	// rename this does nothing
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+// RepoRoot returns the root of the git repository that contains
+// a directory, which is the closest parent with a .git folder or
+// file. If there is none, the error wraps fs.ErrNotExist
+func RepoRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+
+		// Reached the root of the file system
+		if parent == dir {
+			return "", fmt.Errorf("no git repository found: %w", fs.ErrNotExist)
+		}
+
+		dir = parent
+	}
+}
@@ -0,0 +1,134 @@
+package gitgen
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteIgnoreFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, ".gitignore")
+
+	t.Run("New file", func(t *testing.T) {
+		n, err := WriteIgnoreFile(name, []string{"Ada"}, FileOptions{})
+
+		if err != nil || n != len(fullAda) {
+			t.Fatalf("WriteIgnoreFile() = %v, %v, want %v, nil", n, err, len(fullAda))
+		}
+
+		checkFile(t, name, fullAda)
+	})
+
+	t.Run("Refuse to overwrite", func(t *testing.T) {
+		_, err := WriteIgnoreFile(name, []string{"CUDA"}, FileOptions{})
+
+		if !errors.Is(err, fs.ErrExist) {
+			t.Errorf("Expected fs.ErrExist, got %v", err)
+		}
+
+		checkFile(t, name, fullAda)
+	})
+
+	t.Run("Append", func(t *testing.T) {
+		_, err := WriteIgnoreFile(name, []string{"CUDA"}, FileOptions{Append: true})
+
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+
+		checkFile(t, name, fullAda+fullCUDA)
+	})
+
+	t.Run("Force", func(t *testing.T) {
+		_, err := WriteIgnoreFile(name, []string{"CUDA"}, FileOptions{Force: true})
+
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+
+		checkFile(t, name, fullCUDA)
+	})
+
+	t.Run("Bad template leaves the file alone", func(t *testing.T) {
+		_, err := WriteIgnoreFile(name, []string{"WakandaForever"}, FileOptions{Force: true})
+
+		if !errors.Is(err, ErrTemplateNotFound) {
+			t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+		}
+
+		checkFile(t, name, fullCUDA)
+	})
+
+	t.Run("No temporary files left", func(t *testing.T) {
+		entries, _ := os.ReadDir(dir)
+
+		if len(entries) != 1 {
+			t.Errorf("Expected only the .gitignore, got %d files", len(entries))
+		}
+	})
+}
+
+func TestWriteLicenseFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "LICENSE")
+
+	if _, err := WriteLicenseFile(name, "mit", "eacp", "2021", FileOptions{}); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	data, _ := os.ReadFile(name)
+
+	if !strings.Contains(string(data), "Copyright (c) 2021 eacp") {
+		t.Error("The license does not have the name and the year")
+	}
+}
+
+func TestWriteFileAtomic_appendNewline(t *testing.T) {
+	name := filepath.Join(t.TempDir(), ".gitignore")
+
+	os.WriteFile(name, []byte("*.o"), 0600)
+
+	WriteFileAtomic(name, FileOptions{Append: true}, func(w io.Writer) (int, error) {
+		return io.WriteString(w, "*.a\n")
+	})
+
+	checkFile(t, name, "*.o\n*.a\n")
+
+	// The permissions are kept
+	if info, _ := os.Stat(name); info.Mode().Perm() != 0600 {
+		t.Errorf("Expected mode 0600, got %v", info.Mode().Perm())
+	}
+}
+
+func TestRepoRoot(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "a", "b")
+
+	os.MkdirAll(sub, 0755)
+	os.Mkdir(filepath.Join(root, ".git"), 0755)
+
+	got, err := RepoRoot(sub)
+
+	if err != nil || got != root {
+		t.Errorf("RepoRoot() = %v, %v, want %v", got, err, root)
+	}
+}
+
+// Check the content of a file
+func checkFile(t *testing.T, name, want string) {
+	t.Helper()
+
+	data, err := os.ReadFile(name)
+
+	if err != nil {
+		t.Fatal("Could not read the file: ", err)
+	}
+
+	if got := string(data); got != want {
+		t.Errorf("File content = %q, want %q", got, want)
+	}
+}
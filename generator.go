@@ -0,0 +1,49 @@
+package gitgen
+
+// Generator generates gitignore and license files from the
+// templates of a Source. Its methods work like the functions
+// of the package with the same name, which use a Generator
+// with the templates added with AddTemplateFS over the
+// embeded ones. The metadata of the licenses is always the
+// embeded one
+type Generator struct {
+	// Source has the templates. If it is nil
+	// the embeded templates are used
+	Source Source
+}
+
+// NewGenerator returns a Generator that uses the templates of a source
+func NewGenerator(source Source) *Generator {
+	return &Generator{source}
+}
+
+// The generator used by the functions of the package
+func defaultGenerator() *Generator {
+	userLayersMu.RLock()
+	defer userLayersMu.RUnlock()
+
+	layers := make(LayeredSource, 0, len(userLayers)+1)
+	layers = append(layers, userLayers...)
+
+	return &Generator{append(layers, embedded)}
+}
+
+func (g *Generator) source() Source {
+	if g.Source == nil {
+		return embedded
+	}
+
+	return g.Source
+}
+
+// Read a file of the source
+func (g *Generator) asset(name string) ([]byte, error) {
+	return g.source().Get(name)
+}
+
+// Return the files of a folder of the source
+func (g *Generator) listAssets(folder string) []string {
+	names, _ := g.source().List(folder)
+
+	return names
+}
@@ -0,0 +1,133 @@
+package gitgen
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerator(t *testing.T) {
+	// Only the fixtures, without the embeded templates
+	g := NewGenerator(LayeredSource{first, second})
+
+	if got, _ := g.IgnoreText("go"); got != "first" {
+		t.Errorf("IgnoreText() = %v, want first", got)
+	}
+
+	if got := g.ListIgnores(); !reflect.DeepEqual(got, []string{"Go", "Tool", "Zig"}) {
+		t.Errorf("ListIgnores() = %v", got)
+	}
+
+	if got := g.ListLicenses(); !reflect.DeepEqual(got, []string{"acme"}) {
+		t.Errorf("ListLicenses() = %v", got)
+	}
+
+	want := []Template{
+		{"Go", "first", CategoryProject},
+		{"Tool", "first", CategoryProject},
+		{"Zig", "second", CategoryProject},
+	}
+
+	if got := g.IgnoreTemplates(); !reflect.DeepEqual(got, want) {
+		t.Errorf("IgnoreTemplates() = %v, want %v", got, want)
+	}
+
+	b := new(strings.Builder)
+
+	if _, err := g.CombineIgnores([]string{"Tool", "Zig"}, b); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	if want := "# Generated by gitgen from: Tool, Zig\n\n### Tool ###\ntool\n\n### Zig ###\nzig\n"; b.String() != want {
+		t.Errorf("CombineIgnores() = %q, want %q", b.String(), want)
+	}
+
+	// The embeded templates are not there
+	_, err := g.IgnoreText("Yeoman")
+
+	var nf *NotFoundError
+
+	if !errors.As(err, &nf) {
+		t.Fatalf("IgnoreText() error = %v, want a *NotFoundError", err)
+	}
+
+	// The suggestions come from the source too
+	if _, err := g.IgnoreText("Zog"); !strings.Contains(err.Error(), "did you mean Zig?") {
+		t.Errorf("IgnoreText() error = %v", err)
+	}
+}
+
+func TestGenerator_methods(t *testing.T) {
+	g := NewGenerator(LayeredSource{first, second})
+
+	if got := g.GetIgnoreText("zig"); got != "zig" {
+		t.Errorf("GetIgnoreText() = %v, want zig", got)
+	}
+
+	if got := g.GetLicenseText("acme"); got != "acme" {
+		t.Errorf("GetLicenseText() = %v, want acme", got)
+	}
+
+	if got := g.GetLicWithParams("mit", "eacp", "2021"); got != "" {
+		t.Errorf("Expected no MIT in the source, got %q", got)
+	}
+
+	// The licenses of the source have no metadata
+	if _, err := g.LicenseInfo("mit"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("LicenseInfo() error = %v, want ErrTemplateNotFound", err)
+	}
+
+	if got := g.Licenses(); len(got) != 0 {
+		t.Errorf("Licenses() = %v, want none", got)
+	}
+
+	m, err := g.NewTemplateMatcher("Tool")
+
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	if match, _ := m.Match("tool", false); match.Source != "Tool" || !match.Ignored() {
+		t.Errorf("Match() = %+v, want tool from Tool", match)
+	}
+
+	// AddTemplate uses the source of the generator
+	if err := m.AddTemplate("Yeoman"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("AddTemplate() error = %v, want ErrTemplateNotFound", err)
+	}
+
+	// Over the embeded templates the metadata is there
+	g = NewGenerator(LayeredSource{second, Embedded()})
+
+	if got, err := g.LicenseInfo("mit"); err != nil || got.SPDX != "MIT" {
+		t.Errorf("LicenseInfo() = %v, %v", got.SPDX, err)
+	}
+}
+
+func TestGenerator_NilSource(t *testing.T) {
+	g := new(Generator)
+
+	if got := len(g.ListIgnores()); got != 134 {
+		t.Errorf("Expected the 134 embeded templates, got %d", got)
+	}
+
+	if _, err := g.LicenseText("mit"); err != nil {
+		t.Error("Unexpected error: ", err)
+	}
+}
+
+func TestAddTemplateSource(t *testing.T) {
+	old := userLayers
+	defer func() { userLayers = old }()
+
+	AddTemplateSource(second)
+
+	if got := GetIgnoreText("Go"); got != "second" {
+		t.Errorf("GetIgnoreText() = %v, want second", got)
+	}
+
+	if got := len(ListIgnores()); got != 135 {
+		t.Errorf("Expected 135 templates, got %d", got)
+	}
+}
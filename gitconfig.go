@@ -0,0 +1,283 @@
+package gitgen
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigSource gives the values of git config keys, like user.name
+type ConfigSource interface {
+	// Get returns the value of a key and if it was set
+	Get(key string) (string, bool)
+}
+
+// GitConfig is a parsed git config file. The keys are in the form
+// section.key or section.subsection.key, with the section and the
+// key in lower case, as git compares them ignoring the case
+type GitConfig map[string]string
+
+// Get returns the value of a key. The section
+// and the key are not case sensitive
+func (c GitConfig) Get(key string) (string, bool) {
+	val, ok := c[normalizeConfigKey(key)]
+
+	return val, ok
+}
+
+// LayeredConfig looks for a key in several sources,
+// returning the value of the first one that has it
+type LayeredConfig []ConfigSource
+
+// Get returns the value of a key from the first source that has it
+func (l LayeredConfig) Get(key string) (string, bool) {
+	for _, source := range l {
+		if source == nil {
+			continue
+		}
+
+		if val, ok := source.Get(key); ok {
+			return val, true
+		}
+	}
+
+	return "", false
+}
+
+// ParseGitConfig parses a git config file. It supports sections,
+// subsections, quoted values with escapes, comments and lines
+// continued with a backslash. Include directives are not followed
+func ParseGitConfig(r io.Reader) (GitConfig, error) {
+	config := make(GitConfig)
+
+	scanner := bufio.NewScanner(r)
+
+	section := ""
+	number := 0
+
+	for scanner.Scan() {
+		number++
+		line := strings.TrimSpace(scanner.Text())
+
+		// Join the continued lines
+		for strings.HasSuffix(line, "\\") && !strings.HasSuffix(line, "\\\\") && scanner.Scan() {
+			number++
+			line = line[:len(line)-1] + scanner.Text()
+		}
+
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			name, rest, err := parseConfigSection(line)
+
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", number, err)
+			}
+
+			section = name
+			line = rest
+
+			// A key can follow the header in the same line
+			if line == "" || line[0] == '#' || line[0] == ';' {
+				continue
+			}
+		}
+
+		if section == "" {
+			return nil, fmt.Errorf("line %d: key outside of a section", number)
+		}
+
+		key, val := line, "true"
+
+		// A key without = is a boolean set to true
+		if i := strings.IndexByte(line, '='); i >= 0 {
+			key, val = strings.TrimSpace(line[:i]), parseConfigValue(line[i+1:])
+		} else {
+			key = strings.TrimSpace(stripConfigComment(key))
+		}
+
+		config[section+"."+strings.ToLower(key)] = val
+	}
+
+	return config, scanner.Err()
+}
+
+// Parse a [section] or [section "subsection"] header. It returns
+// the name of the section and what comes after the header
+func parseConfigSection(line string) (string, string, error) {
+	end := strings.LastIndexByte(line, ']')
+
+	if end < 0 {
+		return "", "", fmt.Errorf("section not closed: %v", line)
+	}
+
+	header, rest := strings.TrimSpace(line[1:end]), strings.TrimSpace(line[end+1:])
+
+	// [section "subsection"]
+	if i := strings.IndexByte(header, '"'); i >= 0 {
+		name := strings.ToLower(strings.TrimSpace(header[:i]))
+		sub := strings.TrimSuffix(header[i+1:], "\"")
+		sub = strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(sub)
+
+		return name + "." + sub, rest, nil
+	}
+
+	// The old [section.subsection] syntax has a lower case subsection
+	return strings.ToLower(header), rest, nil
+}
+
+// Parse a value, removing the quotes, the escapes and the comments
+func parseConfigValue(raw string) string {
+	b := new(strings.Builder)
+
+	quoted := false
+
+	raw = strings.TrimSpace(raw)
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		switch {
+		case c == '"':
+			quoted = !quoted
+
+		case c == '\\' && i+1 < len(raw):
+			i++
+
+			switch raw[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'b':
+				// A backspace removes the previous character
+				s := b.String()
+
+				if len(s) != 0 {
+					b.Reset()
+					b.WriteString(s[:len(s)-1])
+				}
+			default:
+				b.WriteByte(raw[i])
+			}
+
+		case (c == '#' || c == ';') && !quoted:
+			return strings.TrimRight(b.String(), " \t")
+
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return strings.TrimRight(b.String(), " \t")
+}
+
+// Remove a comment at the end of a line
+func stripConfigComment(line string) string {
+	if i := strings.IndexAny(line, "#;"); i >= 0 {
+		return line[:i]
+	}
+
+	return line
+}
+
+// Make the section and the key lower case, keeping
+// the case of the subsection
+func normalizeConfigKey(key string) string {
+	first := strings.IndexByte(key, '.')
+	last := strings.LastIndexByte(key, '.')
+
+	if first < 0 {
+		return strings.ToLower(key)
+	}
+
+	return strings.ToLower(key[:first]) + key[first:last] + strings.ToLower(key[last:])
+}
+
+// ReadGitConfig parses a git config file from disk
+func ReadGitConfig(name string) (GitConfig, error) {
+	f, err := os.Open(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ParseGitConfig(f)
+}
+
+// UserGitConfig returns the git config that applies to a directory,
+// the same way git reads it: the .git/config of the repository has
+// the highest priority, then ~/.gitconfig and then the XDG config
+// file. Missing or broken files are skipped
+func UserGitConfig(dir string) ConfigSource {
+	var files []string
+
+	if root, err := RepoRoot(dir); err == nil {
+		files = append(files, filepath.Join(gitDir(root), "config"))
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		files = append(files, filepath.Join(home, ".gitconfig"))
+
+		xdg := os.Getenv("XDG_CONFIG_HOME")
+
+		if xdg == "" {
+			xdg = filepath.Join(home, ".config")
+		}
+
+		files = append(files, filepath.Join(xdg, "git", "config"))
+	}
+
+	var layers LayeredConfig
+
+	for _, name := range files {
+		if config, err := ReadGitConfig(name); err == nil {
+			layers = append(layers, config)
+		}
+	}
+
+	return layers
+}
+
+// Get the git folder of a repository, the one with its config and
+// info/exclude. In worktrees and submodules .git is a file pointing
+// to the real folder, and linked worktrees name the folder they
+// share with the main one in a commondir file
+func gitDir(root string) string {
+	dotGit := filepath.Join(root, ".git")
+
+	data, err := os.ReadFile(dotGit)
+
+	// It is a folder, or it can not be read
+	if err != nil {
+		return dotGit
+	}
+
+	dir := strings.TrimSpace(strings.TrimPrefix(string(data), "gitdir:"))
+
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+
+	common, err := os.ReadFile(filepath.Join(dir, "commondir"))
+
+	// Not a linked worktree
+	if err != nil {
+		return dir
+	}
+
+	commonDir := strings.TrimSpace(string(common))
+
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(dir, commonDir)
+	}
+
+	return commonDir
+}
@@ -0,0 +1,183 @@
+package gitgen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleConfig = `# A comment
+[user]
+	name = Eduardo Castillo
+	email = "me@eacp.dev" ; a comment after the value
+[core]
+	bare
+	excludesFile = ~/.gitignore_global
+[remote "Origin"]
+	url = https://github.com/eacp/gitgen\
+.git
+[alias]
+	lg = "log --oneline # not a comment"
+	tabbed = a\tb
+`
+
+func TestParseGitConfig(t *testing.T) {
+	config, err := ParseGitConfig(strings.NewReader(sampleConfig))
+
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	tests := []struct {
+		key, want string
+		ok        bool
+	}{
+		{"user.name", "Eduardo Castillo", true},
+		{"USER.Name", "Eduardo Castillo", true},
+		{"user.email", "me@eacp.dev", true},
+		{"core.bare", "true", true},
+		{"core.excludesfile", "~/.gitignore_global", true},
+		{"remote.Origin.url", "https://github.com/eacp/gitgen.git", true},
+		{"remote.origin.url", "", false}, // Subsections are case sensitive
+		{"alias.lg", "log --oneline # not a comment", true},
+		{"alias.tabbed", "a\tb", true},
+		{"user.missing", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := config.Get(tt.key)
+
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Get(%v) = %q, %v, want %q, %v", tt.key, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseGitConfig_errors(t *testing.T) {
+	tests := []struct {
+		name, text string
+	}{
+		{"Key outside a section", "name = x\n"},
+		{"Section not closed", "[user\nname = x\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseGitConfig(strings.NewReader(tt.text)); err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestLayeredConfig(t *testing.T) {
+	local := GitConfig{"user.name": "Local"}
+	global := GitConfig{"user.name": "Global", "user.email": "g@x.dev"}
+
+	config := LayeredConfig{nil, local, global}
+
+	if got, _ := config.Get("user.name"); got != "Local" {
+		t.Errorf("Expected the local name, got %v", got)
+	}
+
+	if got, _ := config.Get("user.email"); got != "g@x.dev" {
+		t.Errorf("Expected the global email, got %v", got)
+	}
+
+	if _, ok := config.Get("core.bare"); ok {
+		t.Error("Expected core.bare to be missing")
+	}
+}
+
+func TestUserGitConfig(t *testing.T) {
+	home := t.TempDir()
+	repo := t.TempDir()
+
+	// Do not read the real config of the user
+	defer setEnv("HOME", home)()
+	defer setEnv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))()
+
+	os.WriteFile(filepath.Join(home, ".gitconfig"),
+		[]byte("[user]\n\tname = Global\n\temail = g@x.dev\n"), 0644)
+
+	os.MkdirAll(filepath.Join(repo, ".git"), 0755)
+	os.WriteFile(filepath.Join(repo, ".git", "config"),
+		[]byte("[user]\n\tname = Local\n"), 0644)
+
+	config := UserGitConfig(repo)
+
+	if got, _ := config.Get("user.name"); got != "Local" {
+		t.Errorf("Expected the name of the repo, got %v", got)
+	}
+
+	if got, _ := config.Get("user.email"); got != "g@x.dev" {
+		t.Errorf("Expected the global email, got %v", got)
+	}
+}
+
+func Test_gitDir(t *testing.T) {
+	root := t.TempDir()
+
+	// A submodule has a .git file
+	os.WriteFile(filepath.Join(root, ".git"), []byte("gitdir: ../main/.git/modules/x\n"), 0644)
+
+	want := filepath.Join(root, "../main/.git/modules/x")
+
+	if got := gitDir(root); got != want {
+		t.Errorf("gitDir() = %v, want %v", got, want)
+	}
+}
+
+func Test_gitDir_worktree(t *testing.T) {
+	dir := t.TempDir()
+	mainDir := filepath.Join(dir, "main")
+	linked := filepath.Join(dir, "linked")
+	worktree := filepath.Join(mainDir, ".git", "worktrees", "linked")
+
+	os.MkdirAll(worktree, 0755)
+	os.MkdirAll(filepath.Join(mainDir, ".git", "info"), 0755)
+	os.Mkdir(linked, 0755)
+
+	// The files git writes for a linked worktree
+	os.WriteFile(filepath.Join(linked, ".git"), []byte("gitdir: "+worktree+"\n"), 0644)
+	os.WriteFile(filepath.Join(worktree, "commondir"), []byte("../..\n"), 0644)
+	os.WriteFile(filepath.Join(mainDir, ".git", "config"), []byte("[user]\n\tname = Main\n"), 0644)
+	os.WriteFile(filepath.Join(mainDir, ".git", "info", "exclude"), []byte("*.log\n"), 0644)
+
+	want := filepath.Join(mainDir, ".git")
+
+	if got := gitDir(linked); got != want {
+		t.Errorf("gitDir() = %v, want %v", got, want)
+	}
+
+	wantExclude := filepath.Join(mainDir, ".git", "info", "exclude")
+
+	if got, err := LocalExcludesFile(linked); err != nil || got != wantExclude {
+		t.Errorf("LocalExcludesFile() = %v, %v, want %v", got, err, wantExclude)
+	}
+
+	defer setEnv("HOME", t.TempDir())()
+	defer setEnv("XDG_CONFIG_HOME", "")()
+
+	if got, _ := UserGitConfig(linked).Get("user.name"); got != "Main" {
+		t.Errorf("Expected the config of the main worktree, got %v", got)
+	}
+}
+
+// Set an environment variable, returning a function to restore it
+func setEnv(key, val string) func() {
+	old, had := os.LookupEnv(key)
+
+	os.Setenv(key, val)
+
+	return func() {
+		if had {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package gitgen
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Comment is the syntax of the comments of a type of file. Headers
+// are written in a block when Start is set, like /* */, and with
+// a comment per line otherwise, like //
+type Comment struct {
+	// Start opens the block, like /*
+	Start string
+
+	// Prefix starts every line of the header, like // or " * "
+	Prefix string
+
+	// End closes the block, like " */"
+	End string
+}
+
+// The comment syntaxes of the supported files
+var (
+	slashComment     = Comment{Prefix: "// "}
+	hashComment      = Comment{Prefix: "# "}
+	dashComment      = Comment{Prefix: "-- "}
+	semicolonComment = Comment{Prefix: ";; "}
+	percentComment   = Comment{Prefix: "% "}
+	blockComment     = Comment{"/*", " * ", " */"}
+	htmlComment      = Comment{"<!--", "  ", "-->"}
+)
+
+// The comment syntax of each extension
+var extComments = map[string]Comment{
+	".go": slashComment, ".rs": slashComment, ".js": slashComment,
+	".mjs": slashComment, ".cjs": slashComment, ".jsx": slashComment,
+	".ts": slashComment, ".tsx": slashComment, ".java": slashComment,
+	".kt": slashComment, ".kts": slashComment, ".scala": slashComment,
+	".swift": slashComment, ".dart": slashComment, ".cs": slashComment,
+	".fs": slashComment, ".cc": slashComment, ".cpp": slashComment,
+	".cxx": slashComment, ".hpp": slashComment, ".hh": slashComment,
+	".proto": slashComment, ".groovy": slashComment, ".gradle": slashComment,
+	".php": slashComment, ".zig": slashComment,
+
+	".py": hashComment, ".rb": hashComment, ".sh": hashComment,
+	".bash": hashComment, ".zsh": hashComment, ".fish": hashComment,
+	".pl": hashComment, ".pm": hashComment, ".r": hashComment,
+	".yaml": hashComment, ".yml": hashComment, ".toml": hashComment,
+	".tf": hashComment, ".ps1": hashComment, ".nix": hashComment,
+	".cmake": hashComment, ".mk": hashComment, ".ex": hashComment,
+	".exs": hashComment, ".jl": hashComment, ".bzl": hashComment,
+
+	".c": blockComment, ".h": blockComment, ".css": blockComment,
+	".scss": blockComment, ".less": blockComment,
+
+	".html": htmlComment, ".htm": htmlComment, ".xml": htmlComment,
+	".svg": htmlComment, ".vue": htmlComment, ".xsl": htmlComment,
+
+	".sql": dashComment, ".lua": dashComment, ".hs": dashComment,
+	".elm": dashComment,
+
+	".el": semicolonComment, ".lisp": semicolonComment,
+	".clj": semicolonComment, ".cljs": semicolonComment,
+	".scm": semicolonComment,
+
+	".erl": percentComment, ".hrl": percentComment, ".tex": percentComment,
+}
+
+// The comment syntax of files without an extension
+var nameComments = map[string]Comment{
+	"Makefile":       hashComment,
+	"Dockerfile":     hashComment,
+	"Gemfile":        hashComment,
+	"Rakefile":       hashComment,
+	"CMakeLists.txt": hashComment,
+	"BUILD":          hashComment,
+	"BUILD.bazel":    hashComment,
+	"WORKSPACE":      hashComment,
+}
+
+// CommentFor returns the comment syntax of a file from its name.
+// It is false if the type of the file is not supported
+func CommentFor(path string) (Comment, bool) {
+	base := filepath.Base(path)
+
+	if c, ok := nameComments[base]; ok {
+		return c, true
+	}
+
+	c, ok := extComments[strings.ToLower(filepath.Ext(base))]
+
+	return c, ok
+}
+
+// Format turns a text into a comment
+func (c Comment) Format(text string) string {
+	b := new(strings.Builder)
+
+	if c.Start != "" {
+		b.WriteString(c.Start + "\n")
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		b.WriteString(strings.TrimRight(c.Prefix+line, " ") + "\n")
+	}
+
+	if c.End != "" {
+		b.WriteString(c.End + "\n")
+	}
+
+	return b.String()
+}
+
+// HeaderText returns the text of the license header of the source
+// files, without comments: the notice of the license, see Notice,
+// and its SPDX identifier. The header is copied to every file, so
+// it fails with a *PlaceholderError if a placeholder has no value
+func HeaderText(key string, params LicenseParams) (string, error) {
+	return defaultGenerator().HeaderText(key, params)
+}
+
+// HeaderText returns the text of the license header
+// of a license of the source
+func (g *Generator) HeaderText(key string, params LicenseParams) (string, error) {
+	b := new(strings.Builder)
+
+	if _, err := g.RenderNoticeStrict(key, params, b); err != nil {
+		return "", err
+	}
+
+	// The default notice already has it
+	if g.HasNotice(key) {
+		b.WriteString("\nSPDX-License-Identifier: " + headerSPDX(g.resolveLicense(key)) + "\n")
+	}
+
+	return b.String(), nil
+}
+
+// How many lines at the top of a file are searched for a header.
+// The GNU notices take almost 20
+const headerLines = 30
+
+// A copyright in a comment, like "// Copyright (c) 2021", or in
+// a line indented inside a comment block. A line that only
+// mentions the copyright, or code, is not a header
+var headerCopyright = regexp.MustCompile(
+	`(?i)^(?:\s*(?://+|#+|--|;+|%+|/\*+|\*+|<!--)\s*|\s+)copyright\s+(?:\(c\)\s*|©\s*)?\d{4}\b`)
+
+// HasHeader tells if a file starts with a license header: if its
+// first lines have an SPDX identifier or a comment with a
+// copyright and its year, like "# Copyright (c) 2021 ACME"
+func HasHeader(src []byte) bool {
+	lines := bytes.SplitN(src, []byte("\n"), headerLines+1)
+
+	if len(lines) > headerLines {
+		lines = lines[:headerLines]
+	}
+
+	for _, line := range lines {
+		if bytes.Contains(line, []byte("SPDX-License-Identifier")) ||
+			headerCopyright.Match(line) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The marker of generated files, like the one of Go
+// after any comment characters
+var generatedMarker = regexp.MustCompile(`(?m)^\W*Code generated .*DO NOT EDIT`)
+
+// IsGenerated tells if a file is generated, like the Go files with a
+// "// Code generated ... DO NOT EDIT." line. Headers are not added
+// to them, as they would be lost the next time they are generated
+func IsGenerated(src []byte) bool {
+	return generatedMarker.Match(src)
+}
+
+// Lines that must stay at the very top of a file,
+// so the header goes after them
+var topLines = []string{"#!", "<?xml", "<!doctype", "<?php"}
+
+// The encoding declarations of Python, which only work in
+// the first two lines, like # -*- coding: latin-1 -*-
+var codingLine = regexp.MustCompile(`^[ \t\f]*#.*?coding[:=]`)
+
+// InsertHeader returns the content of a file with a header at the
+// top, in a comment, followed by a blank line. Shebangs, XML
+// declarations and the like stay in the first line, and Python
+// encoding declarations in the first two. Go build constraints
+// stay before the package clause, after the header
+func InsertHeader(src []byte, header string, c Comment) []byte {
+	first, rest := cutLine(src)
+	second, after := cutLine(rest)
+
+	var top []byte
+
+	switch {
+	case codingLine.Match(second):
+		top, rest = append(first, second...), after
+
+	case isTopLine(first):
+		top = first
+
+	default:
+		rest = src
+	}
+
+	if len(top) != 0 && top[len(top)-1] != '\n' {
+		top = append(top, '\n')
+	}
+
+	out := append([]byte{}, top...)
+	out = append(out, c.Format(header)...)
+
+	// Separate it from the code, so it is not the doc
+	// comment of a package or a build constraint
+	if len(rest) != 0 && rest[0] != '\n' {
+		out = append(out, '\n')
+	}
+
+	return append(out, rest...)
+}
+
+// Tell if a line must stay at the top of a file
+func isTopLine(line []byte) bool {
+	if codingLine.Match(line) {
+		return true
+	}
+
+	for _, prefix := range topLines {
+		if bytes.HasPrefix(bytes.ToLower(line), []byte(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Split the first line of some data, with its
+// line ending, from the rest
+func cutLine(data []byte) (line, rest []byte) {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return data[: i+1 : i+1], data[i+1:]
+	}
+
+	return data, nil
+}
+
+// What happened to a file when adding headers
+const (
+	// The header was added
+	HeaderAdded = "added"
+
+	// The file already had a header
+	HeaderPresent = "present"
+
+	// The file is generated and was skipped
+	HeaderGenerated = "generated"
+
+	// The type of the file is not supported
+	HeaderUnsupported = "unsupported"
+)
+
+// HeaderResult tells what happened to a file
+type HeaderResult struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+}
+
+// AddHeaders adds a license header, like the one of HeaderText, to
+// the source files of some paths and returns what happened to each
+// file. The paths work like the packages of the go command: a
+// folder has its files, and dir/... has the files of all its
+// subfolders too, except hidden and dependency folders and the
+// ones ignored by git. Files that already have a header or are
+// generated are not touched
+func AddHeaders(header string, paths ...string) ([]HeaderResult, error) {
+	files, err := sourceFiles(paths)
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]HeaderResult, 0, len(files))
+
+	for _, file := range files {
+		status, err := addHeader(file, header)
+
+		if err != nil {
+			return results, err
+		}
+
+		results = append(results, HeaderResult{file, status})
+	}
+
+	return results, nil
+}
+
+// Add a header to a file if it needs one
+func addHeader(file, header string) (string, error) {
+	c, ok := CommentFor(file)
+
+	if !ok {
+		return HeaderUnsupported, nil
+	}
+
+	src, err := os.ReadFile(file)
+
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case IsGenerated(src):
+		return HeaderGenerated, nil
+	case HasHeader(src):
+		return HeaderPresent, nil
+	}
+
+	_, err = WriteFileAtomic(file, FileOptions{Force: true}, func(w io.Writer) (int, error) {
+		return w.Write(InsertHeader(src, header, c))
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return HeaderAdded, nil
+}
@@ -0,0 +1,357 @@
+package gitgen
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommentFor(t *testing.T) {
+	tests := []struct {
+		path string
+		want Comment
+		ok   bool
+	}{
+		{"main.go", slashComment, true},
+		{"src/app.TS", slashComment, true},
+		{"setup.py", hashComment, true},
+		{"lib/util.c", blockComment, true},
+		{"index.html", htmlComment, true},
+		{"schema.sql", dashComment, true},
+		{"build/Makefile", hashComment, true},
+		{"README.md", Comment{}, false},
+		{"LICENSE", Comment{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := CommentFor(tt.path)
+
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("CommentFor() = %q, %v, want %q, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestComment_Format(t *testing.T) {
+	text := "Copyright (c) 2021 ACME\n\nSPDX-License-Identifier: MIT\n"
+
+	tests := []struct {
+		name string
+		c    Comment
+		want string
+	}{
+		{
+			"Line comments",
+			slashComment,
+			"// Copyright (c) 2021 ACME\n//\n// SPDX-License-Identifier: MIT\n",
+		},
+
+		{
+			"Block comments",
+			blockComment,
+			"/*\n * Copyright (c) 2021 ACME\n *\n * SPDX-License-Identifier: MIT\n */\n",
+		},
+
+		{
+			"HTML",
+			htmlComment,
+			"<!--\n  Copyright (c) 2021 ACME\n\n  SPDX-License-Identifier: MIT\n-->\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Format(text); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaderText(t *testing.T) {
+	params := LicenseParams{Holders: []string{"ACME"}, Year: "2021"}
+
+	got, err := HeaderText("apache-2.0", params)
+
+	if err != nil || got != "Copyright (c) 2021 ACME\n\nSPDX-License-Identifier: Apache-2.0\n" {
+		t.Errorf("HeaderText() = %q, %v", got, err)
+	}
+
+	// The GNU notice and the identifier
+	params.Project = "gitgen"
+
+	got, err = HeaderText("gpl-3.0", params)
+
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	if !strings.HasPrefix(got, "gitgen\nCopyright (C) 2021  ACME\n") ||
+		!strings.HasSuffix(got, "<https://www.gnu.org/licenses/>.\n\nSPDX-License-Identifier: GPL-3.0-or-later\n") {
+		t.Errorf("HeaderText() = %q", got)
+	}
+
+	// Every placeholder must be filled
+	if _, err := HeaderText("mit", LicenseParams{Year: "2021"}); !errors.Is(err, ErrUnfilledPlaceholders) {
+		t.Errorf("Expected ErrUnfilledPlaceholders, got %v", err)
+	}
+}
+
+func TestHeaderText_identifier(t *testing.T) {
+	params := LicenseParams{Holders: []string{"ACME"}, Year: "2021", Project: "gitgen"}
+
+	// The GNU notices allow any later version
+	tests := []struct {
+		key, want string
+	}{
+		{"mit", "SPDX-License-Identifier: MIT"},
+		{"mpl-2.0", "SPDX-License-Identifier: MPL-2.0"},
+		{"gpl-2.0", "SPDX-License-Identifier: GPL-2.0-or-later"},
+		{"gpl-3.0", "SPDX-License-Identifier: GPL-3.0-or-later"},
+		{"lgpl-2.1", "SPDX-License-Identifier: LGPL-2.1-or-later"},
+		{"agpl-3.0", "SPDX-License-Identifier: AGPL-3.0-or-later"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := HeaderText(tt.key, params)
+
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+			if last := lines[len(lines)-1]; last != tt.want {
+				t.Errorf("The last line of HeaderText() = %q, want %q", last, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasHeader(t *testing.T) {
+	tests := []struct {
+		name, src string
+		want      bool
+	}{
+		{"SPDX", "// SPDX-License-Identifier: MIT\npackage main\n", true},
+		{"Copyright", "#!/bin/sh\n# Copyright 2021 ACME\necho hi\n", true},
+		{"Block", "/*\n * Copyright (c) 2021 ACME\n */\nint x;\n", true},
+		{"HTML", "<!--\n  Copyright © 2021 ACME\n-->\n<p>hi</p>\n", true},
+		{"Mention", "// See the copyright file\npackage main\n", false},
+		{"Code", "fmt.Println(\"Copyright 2021 ACME\")\n", false},
+		{"No header", "package main\n\nfunc main() {}\n", false},
+		{"Too far", strings.Repeat("\n", headerLines) + "// Copyright 2021 ACME\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasHeader([]byte(tt.src)); got != tt.want {
+				t.Errorf("HasHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsGenerated(t *testing.T) {
+	tests := []struct {
+		name, src string
+		want      bool
+	}{
+		{"Go", "// Code generated by stringer; DO NOT EDIT.\n\npackage main\n", true},
+		{"After build constraints", "//go:build linux\n\n// Code generated by go generate. DO NOT EDIT.\n", true},
+		{"Python", "# Code generated by protoc. DO NOT EDIT.\n", true},
+		{"Not generated", "package main\n\n// Code for the generated files\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsGenerated([]byte(tt.src)); got != tt.want {
+				t.Errorf("IsGenerated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertHeader(t *testing.T) {
+	header := "Copyright (c) 2021 ACME\n"
+
+	tests := []struct {
+		name, src string
+		c         Comment
+		want      string
+	}{
+		{
+			"Go",
+			"package main\n",
+			slashComment,
+			"// Copyright (c) 2021 ACME\n\npackage main\n",
+		},
+
+		{
+			"Go build constraints",
+			"//go:build linux\n\npackage main\n",
+			slashComment,
+			"// Copyright (c) 2021 ACME\n\n//go:build linux\n\npackage main\n",
+		},
+
+		{
+			"Shebang",
+			"#!/usr/bin/env python3\nprint('hi')\n",
+			hashComment,
+			"#!/usr/bin/env python3\n# Copyright (c) 2021 ACME\n\nprint('hi')\n",
+		},
+
+		{
+			"Shebang and encoding",
+			"#!/usr/bin/env python\n# -*- coding: latin-1 -*-\nprint('hi')\n",
+			hashComment,
+			"#!/usr/bin/env python\n# -*- coding: latin-1 -*-\n# Copyright (c) 2021 ACME\n\nprint('hi')\n",
+		},
+
+		{
+			"Encoding",
+			"# vim: set fileencoding=utf-8 :\nprint('hi')\n",
+			hashComment,
+			"# vim: set fileencoding=utf-8 :\n# Copyright (c) 2021 ACME\n\nprint('hi')\n",
+		},
+
+		{
+			"Encoding in the second line",
+			"# Tools\n# coding=utf-8\nprint('hi')\n",
+			hashComment,
+			"# Tools\n# coding=utf-8\n# Copyright (c) 2021 ACME\n\nprint('hi')\n",
+		},
+
+		{
+			"Encoding after the second line",
+			"print('hi')\n\n# coding: utf-8\n",
+			hashComment,
+			"# Copyright (c) 2021 ACME\n\nprint('hi')\n\n# coding: utf-8\n",
+		},
+
+		{
+			"XML declaration",
+			"<?xml version=\"1.0\"?>\n<svg/>\n",
+			htmlComment,
+			"<?xml version=\"1.0\"?>\n<!--\n  Copyright (c) 2021 ACME\n-->\n\n<svg/>\n",
+		},
+
+		{
+			"Starts with a blank line",
+			"\nint main() {}\n",
+			blockComment,
+			"/*\n * Copyright (c) 2021 ACME\n */\n\nint main() {}\n",
+		},
+
+		{
+			"Only a shebang",
+			"#!/bin/sh",
+			hashComment,
+			"#!/bin/sh\n# Copyright (c) 2021 ACME\n",
+		},
+
+		{
+			"Empty file",
+			"",
+			slashComment,
+			"// Copyright (c) 2021 ACME\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(InsertHeader([]byte(tt.src), header, tt.c)); got != tt.want {
+				t.Errorf("InsertHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddHeaders(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"main.go":              "package main\n",
+		"gen.go":               "// Code generated by stringer; DO NOT EDIT.\n\npackage main\n",
+		"old.go":               "// Copyright 2019 ACME\n\npackage main\n",
+		"README.md":            "# Project\n",
+		"scripts/run.sh":       "#!/bin/sh\necho hi\n",
+		"node_modules/dep.js":  "module.exports = {}\n",
+		".hidden/secret.py":    "print('hi')\n",
+		"web/static/index.css": "body {}\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+
+	os.Chmod(filepath.Join(dir, "scripts", "run.sh"), 0755)
+
+	header := "Copyright (c) 2021 ACME\n\nSPDX-License-Identifier: MIT\n"
+
+	results, err := AddHeaders(header, filepath.Join(dir, "..."))
+
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	want := []HeaderResult{
+		{"README.md", HeaderUnsupported},
+		{"gen.go", HeaderGenerated},
+		{"main.go", HeaderAdded},
+		{"old.go", HeaderPresent},
+		{"scripts/run.sh", HeaderAdded},
+		{"web/static/index.css", HeaderAdded},
+	}
+
+	if len(results) != len(want) {
+		t.Fatalf("AddHeaders() = %v, want %v", results, want)
+	}
+
+	for i, result := range results {
+		rel, _ := filepath.Rel(dir, result.Path)
+
+		if filepath.ToSlash(rel) != want[i].Path || result.Status != want[i].Status {
+			t.Errorf("AddHeaders()[%d] = %v %v, want %v", i, rel, result.Status, want[i])
+		}
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, "scripts", "run.sh"))
+
+	if got := string(data); got != "#!/bin/sh\n# Copyright (c) 2021 ACME\n#\n# SPDX-License-Identifier: MIT\n\necho hi\n" {
+		t.Errorf("run.sh = %q", got)
+	}
+
+	if info, _ := os.Stat(filepath.Join(dir, "scripts", "run.sh")); info.Mode().Perm() != 0755 {
+		t.Errorf("The mode changed to %v", info.Mode().Perm())
+	}
+
+	// A second run adds nothing
+	results, _ = AddHeaders(header, filepath.Join(dir, "..."))
+
+	for _, result := range results {
+		if result.Status == HeaderAdded {
+			t.Errorf("%v got a second header", result.Path)
+		}
+	}
+
+	// Without ... only the files of the folder
+	results, _ = AddHeaders(header, filepath.Join(dir, "web"))
+
+	if len(results) != 0 {
+		t.Errorf("AddHeaders() = %v for a folder without files", results)
+	}
+
+	if _, err := AddHeaders(header, filepath.Join(dir, "nope")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected fs.ErrNotExist, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package gitgen
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The problems of a license header
+const (
+	// The file has no SPDX identifier
+	HeaderMissing = "missing"
+
+	// The SPDX identifier is not the one of the project
+	HeaderWrongLicense = "license"
+
+	// The copyright line is missing or has no year and holder
+	HeaderMalformed = "malformed"
+
+	// The copyright does not reach the expected year
+	HeaderStale = "stale"
+)
+
+// HeaderProblem is something wrong in the license header of a file
+type HeaderProblem struct {
+	Path string `json:"path"`
+
+	// Line is the line of the problem, starting at 1
+	Line int `json:"line"`
+
+	// Kind is HeaderMissing, HeaderWrongLicense,
+	// HeaderMalformed or HeaderStale
+	Kind string `json:"kind"`
+
+	Message string `json:"message"`
+}
+
+// HeaderPolicy is what the license headers of a project must have
+type HeaderPolicy struct {
+	// License is the license of the project. The key is case
+	// insensitive and can be an alias, like in LicenseText. Its
+	// -only and -or-later identifiers are fine, like GPL-3.0-only,
+	// and so are OR expressions with it, like MIT OR Apache-2.0.
+	// Expressions with AND or WITH are not supported
+	License string
+
+	// Year, if it is not 0, is the year the copyright
+	// must reach, like 2021 for 2019-2021
+	Year int
+}
+
+var (
+	spdxLine = regexp.MustCompile(`SPDX-License-Identifier:\s*(.*?)\s*(?:\*/|-->)?\s*$`)
+
+	// Copyright, an optional (c), the years and the holder
+	copyrightLine = regexp.MustCompile(`(?i)copyright\s+(?:\(c\)\s*|©\s*)?(\d{4}(?:\s*[-,]\s*\d{4})*),?\s+\S`)
+
+	yearPattern = regexp.MustCompile(`\d{4}`)
+
+	// The operator of the SPDX expressions like MIT OR Apache-2.0
+	orOperator = regexp.MustCompile(`\s+(?:OR|or)\s+`)
+)
+
+// CheckHeader returns the problems of the license header of a file.
+// The paths of the problems are empty
+func CheckHeader(src []byte, policy HeaderPolicy) []HeaderProblem {
+	id := headerSPDX(resolveLicense(policy.License))
+
+	lines := bytes.SplitN(src, []byte("\n"), headerLines+1)
+
+	if len(lines) > headerLines {
+		lines = lines[:headerLines]
+	}
+
+	var problems []HeaderProblem
+
+	spdx, copyright := 0, 0
+
+	for i, line := range lines {
+		text := string(line)
+
+		if m := spdxLine.FindStringSubmatch(text); m != nil && spdx == 0 {
+			spdx = i + 1
+
+			if !matchesLicense(m[1], id) {
+				problems = append(problems, HeaderProblem{"", spdx, HeaderWrongLicense,
+					fmt.Sprintf("The license is %v instead of %v", m[1], id)})
+			}
+		}
+
+		if strings.Contains(strings.ToLower(text), "copyright") && copyright == 0 {
+			copyright = i + 1
+			problems = append(problems, checkCopyright(text, copyright, policy.Year)...)
+		}
+	}
+
+	if spdx == 0 {
+		return append([]HeaderProblem{{"", 1, HeaderMissing,
+			"There is no SPDX-License-Identifier"}}, problems...)
+	}
+
+	if copyright == 0 {
+		problems = append(problems, HeaderProblem{"", spdx, HeaderMalformed,
+			"There is no copyright line"})
+	}
+
+	return problems
+}
+
+// Tell if an SPDX expression allows the license with an identifier
+func matchesLicense(expr, id string) bool {
+	for _, operand := range orOperator.Split(expr, -1) {
+		if strings.EqualFold(baseSPDX(strings.Trim(operand, "() ")), baseSPDX(id)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Get an SPDX identifier without the -only, -or-later
+// or + of the GNU licenses, like GPL-3.0
+func baseSPDX(id string) string {
+	id = strings.TrimSuffix(id, "+")
+	lower := strings.ToLower(id)
+
+	for _, suffix := range []string{"-only", "-or-later"} {
+		if strings.HasSuffix(lower, suffix) {
+			return id[:len(id)-len(suffix)]
+		}
+	}
+
+	return id
+}
+
+// Check the years and the holder of a copyright line
+func checkCopyright(text string, line, want int) []HeaderProblem {
+	m := copyrightLine.FindStringSubmatch(text)
+
+	if m == nil {
+		return []HeaderProblem{{"", line, HeaderMalformed,
+			"The copyright line has no year or holder"}}
+	}
+
+	// The last year of a range or a list
+	years := yearPattern.FindAllString(m[1], -1)
+	last, _ := strconv.Atoi(years[len(years)-1])
+
+	if want != 0 && last < want {
+		return []HeaderProblem{{"", line, HeaderStale,
+			fmt.Sprintf("The copyright ends in %d instead of %d", last, want)}}
+	}
+
+	return nil
+}
+
+// CheckHeaders checks the license headers of the source files of
+// some paths, found like AddHeaders does, and returns every
+// problem. Generated files and files that are not supported
+// are skipped. If the license does not exist the error
+// wraps ErrTemplateNotFound
+func CheckHeaders(policy HeaderPolicy, paths ...string) ([]HeaderProblem, error) {
+	if _, err := LicenseText(policy.License); err != nil {
+		return nil, err
+	}
+
+	files, err := sourceFiles(paths)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []HeaderProblem
+
+	for _, file := range files {
+		if _, ok := CommentFor(file); !ok {
+			continue
+		}
+
+		src, err := os.ReadFile(file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if IsGenerated(src) {
+			continue
+		}
+
+		for _, p := range CheckHeader(src, policy) {
+			p.Path = file
+			problems = append(problems, p)
+		}
+	}
+
+	return problems, nil
+}
@@ -0,0 +1,213 @@
+package gitgen
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckHeader(t *testing.T) {
+	policy := HeaderPolicy{License: "apache-2.0", Year: 2021}
+
+	tests := []struct {
+		name, src string
+		want      []HeaderProblem
+	}{
+		{
+			"Good header",
+			"// Copyright (c) 2021 ACME\n//\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n",
+			nil,
+		},
+
+		{
+			"Range of years and a block comment",
+			"/*\n * Copyright 2019-2021 ACME\n * SPDX-License-Identifier: apache-2.0 */\n",
+			nil,
+		},
+
+		{
+			"No header",
+			"package main\n",
+			[]HeaderProblem{{"", 1, HeaderMissing, "There is no SPDX-License-Identifier"}},
+		},
+
+		{
+			"Only a copyright",
+			"# Copyright 2021 ACME\nprint('hi')\n",
+			[]HeaderProblem{{"", 1, HeaderMissing, "There is no SPDX-License-Identifier"}},
+		},
+
+		{
+			"OR expression",
+			"// Copyright 2021 ACME\n// SPDX-License-Identifier: (MIT OR Apache-2.0)\n",
+			nil,
+		},
+
+		{
+			"OR expression without the license",
+			"// Copyright 2021 ACME\n// SPDX-License-Identifier: MIT OR BSD-3-Clause\n",
+			[]HeaderProblem{{"", 2, HeaderWrongLicense, "The license is MIT OR BSD-3-Clause instead of Apache-2.0"}},
+		},
+
+		{
+			"AND expression",
+			"// Copyright 2021 ACME\n// SPDX-License-Identifier: MIT AND Apache-2.0\n",
+			[]HeaderProblem{{"", 2, HeaderWrongLicense, "The license is MIT AND Apache-2.0 instead of Apache-2.0"}},
+		},
+
+		{
+			"Another license",
+			"// Copyright 2021 ACME\n// SPDX-License-Identifier: MIT\n",
+			[]HeaderProblem{{"", 2, HeaderWrongLicense, "The license is MIT instead of Apache-2.0"}},
+		},
+
+		{
+			"Without a copyright",
+			"#!/bin/sh\n# SPDX-License-Identifier: Apache-2.0\n",
+			[]HeaderProblem{{"", 2, HeaderMalformed, "There is no copyright line"}},
+		},
+
+		{
+			"Unfilled copyright",
+			"// Copyright (c) [year] [fullname]\n// SPDX-License-Identifier: Apache-2.0\n",
+			[]HeaderProblem{{"", 1, HeaderMalformed, "The copyright line has no year or holder"}},
+		},
+
+		{
+			"Stale copyright",
+			"// Copyright 2018, 2019 ACME\n// SPDX-License-Identifier: Apache-2.0\n",
+			[]HeaderProblem{{"", 1, HeaderStale, "The copyright ends in 2019 instead of 2021"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckHeader([]byte(tt.src), policy)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("CheckHeader() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("CheckHeader()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	// Any year is fine without one in the policy
+	if got := CheckHeader([]byte("// Copyright 1999 ACME\n// SPDX-License-Identifier: MIT\n"), HeaderPolicy{License: "mit"}); got != nil {
+		t.Errorf("CheckHeader() = %v", got)
+	}
+}
+
+func TestCheckHeader_gnu(t *testing.T) {
+	policy := HeaderPolicy{License: "gpl-3.0"}
+
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"GPL-3.0-or-later", true},
+		{"GPL-3.0-only", true},
+		{"GPL-3.0", true},
+		{"GPL-3.0+", true},
+		{"gpl-3.0-or-later", true},
+		{"MIT OR GPL-3.0-only", true},
+		{"GPL-2.0-or-later", false},
+		{"LGPL-3.0-or-later", false},
+		{"AGPL-3.0-only", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			src := "# Copyright 2021 ACME\n# SPDX-License-Identifier: " + tt.id + "\n"
+
+			if got := CheckHeader([]byte(src), policy); (got == nil) != tt.want {
+				t.Errorf("CheckHeader() = %v, want no problems %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckHeader_Added(t *testing.T) {
+	// The headers of HeaderText pass, even the long GNU ones
+	params := LicenseParams{Holders: []string{"ACME"}, Year: "2021", Project: "gitgen"}
+
+	for _, key := range []string{"mit", "gpl-3.0", "lgpl-2.1"} {
+		t.Run(key, func(t *testing.T) {
+			header, err := HeaderText(key, params)
+
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			src := InsertHeader([]byte("#!/bin/sh\necho hi\n"), header, blockComment)
+
+			if got := CheckHeader(src, HeaderPolicy{License: key, Year: 2021}); got != nil {
+				t.Errorf("CheckHeader() = %v", got)
+			}
+		})
+	}
+}
+
+func TestCheckHeaders(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		".gitignore":        "/build-output/\n*.tmp.go\n!keep.tmp.go\n",
+		"main.go":           "// Copyright 2021 ACME\n// SPDX-License-Identifier: MIT\n\npackage main\n",
+		"util.go":           "package main\n",
+		"gen.go":            "// Code generated by stringer; DO NOT EDIT.\n\npackage main\n",
+		"notes.md":          "# Notes\n",
+		"scratch.tmp.go":    "package main\n",
+		"keep.tmp.go":       "package main\n",
+		"build-output/x.go": "package x\n",
+		"lib/.gitignore":    "old.py\n",
+		"lib/old.py":        "print('old')\n",
+		"lib/new.py":        "print('new')\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+
+	// The root of the repository
+	os.Mkdir(filepath.Join(dir, ".git"), 0755)
+
+	problems, err := CheckHeaders(HeaderPolicy{License: "mit"}, filepath.Join(dir, "..."))
+
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	want := []string{"keep.tmp.go", "lib/new.py", "util.go"}
+
+	if len(problems) != len(want) {
+		t.Fatalf("CheckHeaders() = %v, want problems in %v", problems, want)
+	}
+
+	for i, p := range problems {
+		rel, _ := filepath.Rel(dir, p.Path)
+
+		if filepath.ToSlash(rel) != want[i] || p.Kind != HeaderMissing {
+			t.Errorf("CheckHeaders()[%d] = %v %v, want %v missing", i, rel, p.Kind, want[i])
+		}
+	}
+
+	// The ignored files of a subfolder are ignored too
+	problems, _ = CheckHeaders(HeaderPolicy{License: "mit"}, filepath.Join(dir, "lib"))
+
+	if len(problems) != 1 {
+		t.Errorf("CheckHeaders() = %v, want only lib/new.py", problems)
+	}
+
+	if _, err := CheckHeaders(HeaderPolicy{License: "mti"}, dir); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,310 @@
+package gitgen
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// LineKind tells what a line of a .gitignore file contains
+type LineKind int
+
+const (
+	// LineBlank is an empty line or a line with only spaces
+	LineBlank LineKind = iota
+
+	// LineComment is a line starting with #
+	LineComment
+
+	// LineSection is a comment like "### Node ###" that starts
+	// a section, as written by CombineIgnores
+	LineSection
+
+	// LinePattern is a line with a pattern
+	LinePattern
+)
+
+func (k LineKind) String() string {
+	switch k {
+	case LineBlank:
+		return "blank"
+	case LineComment:
+		return "comment"
+	case LineSection:
+		return "section"
+	case LinePattern:
+		return "pattern"
+	}
+
+	return "unknown"
+}
+
+// Rule is a parsed gitignore pattern
+type Rule struct {
+	// Pattern is the glob without the leading !, the leading /
+	// or the trailing /. Escapes are kept as they are
+	Pattern string
+
+	// Negated is true for patterns starting with !, which
+	// re-include paths excluded by a previous pattern
+	Negated bool
+
+	// DirOnly is true for patterns ending with /, which
+	// only match directories
+	DirOnly bool
+
+	// Anchored is true if the pattern has a / at the beginning
+	// or the middle, so it is relative to the .gitignore
+	// location instead of matching at any level
+	Anchored bool
+}
+
+// Line is a single line of a .gitignore file
+type Line struct {
+	Kind LineKind
+
+	// Text is the line as it appears in the file, without the
+	// line ending
+	Text string
+
+	// Ending is "\n", "\r\n" or "" for a last line
+	// without a line ending
+	Ending string
+
+	// Number is the line number, starting at 1
+	Number int
+
+	// Section is the name of a LineSection, otherwise empty
+	Section string
+
+	// Rule is set for LinePattern lines. It is nil for
+	// patterns that can not match anything, like "/" or "!"
+	Rule *Rule
+}
+
+// IgnoreFile is a parsed .gitignore file. Writing it back
+// produces exactly the bytes that were parsed
+type IgnoreFile struct {
+	Lines []Line
+}
+
+// Section is a group of lines under a section header. The
+// lines before the first header have an empty name
+type Section struct {
+	Name string
+
+	// Lines includes the header itself
+	Lines []Line
+}
+
+// ParseIgnore parses a .gitignore file from a reader
+func ParseIgnore(r io.Reader) (*IgnoreFile, error) {
+	data, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIgnoreBytes(data), nil
+}
+
+// ParseIgnoreTemplate parses one of the embeded gitignore
+// templates. If it does not exist the error wraps
+// ErrTemplateNotFound
+func ParseIgnoreTemplate(key string) (*IgnoreFile, error) {
+	return defaultGenerator().ParseIgnoreTemplate(key)
+}
+
+// ParseIgnoreTemplate parses a gitignore template of the source
+func (g *Generator) ParseIgnoreTemplate(key string) (*IgnoreFile, error) {
+	data, err := g.ignoreAsset(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIgnoreBytes(data), nil
+}
+
+func parseIgnoreBytes(data []byte) *IgnoreFile {
+	f := new(IgnoreFile)
+
+	for number := 1; len(data) != 0; number++ {
+		var text, ending string
+
+		// Split the line and its ending
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			text, ending = string(data[:i]), "\n"
+			data = data[i+1:]
+
+			if strings.HasSuffix(text, "\r") {
+				text, ending = text[:len(text)-1], "\r\n"
+			}
+		} else {
+			text = string(data)
+			data = nil
+		}
+
+		line := parseLine(text)
+		line.Ending = ending
+		line.Number = number
+
+		f.Lines = append(f.Lines, line)
+	}
+
+	return f
+}
+
+// Parse the text of a single line
+func parseLine(text string) Line {
+	line := Line{Text: text}
+
+	trimmed := strings.TrimSpace(text)
+
+	switch {
+	case trimmed == "":
+		line.Kind = LineBlank
+
+	case strings.HasPrefix(text, "#"):
+		line.Kind = LineComment
+
+		if name, ok := sectionName(text); ok {
+			line.Kind = LineSection
+			line.Section = name
+		}
+
+	default:
+		line.Kind = LinePattern
+		line.Rule = parseRule(text)
+	}
+
+	return line
+}
+
+// Get the name of a "### Name ###" header
+func sectionName(text string) (string, bool) {
+	if !strings.HasPrefix(text, "###") {
+		return "", false
+	}
+
+	name := strings.TrimSpace(strings.Trim(strings.TrimSpace(text), "#"))
+
+	if name == "" || !strings.HasSuffix(strings.TrimSpace(text), "###") {
+		return "", false
+	}
+
+	return name, true
+}
+
+// Parse a pattern line following the gitignore rules
+func parseRule(text string) *Rule {
+	rule := new(Rule)
+
+	pattern := trimTrailingSpaces(text)
+
+	// A leading ! negates, \! and \# are literal
+	if strings.HasPrefix(pattern, "!") {
+		rule.Negated = true
+		pattern = pattern[1:]
+	}
+
+	if strings.HasSuffix(pattern, "/") && !strings.HasSuffix(pattern, "\\/") {
+		rule.DirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+
+	// A slash at the beginning or the middle anchors the pattern
+	if strings.Contains(pattern, "/") {
+		rule.Anchored = true
+		pattern = strings.TrimPrefix(pattern, "/")
+	}
+
+	if pattern == "" {
+		return nil
+	}
+
+	rule.Pattern = pattern
+
+	return rule
+}
+
+// Remove the trailing spaces, unless they are escaped with \
+func trimTrailingSpaces(text string) string {
+	end := len(text)
+
+	for end > 0 && (text[end-1] == ' ' || text[end-1] == '\t') {
+		// Count the backslashes before the space
+		slashes := 0
+
+		for i := end - 2; i >= 0 && text[i] == '\\'; i-- {
+			slashes++
+		}
+
+		// An odd number means the space is escaped
+		if slashes%2 == 1 {
+			break
+		}
+
+		end--
+	}
+
+	return text[:end]
+}
+
+// Rules returns the patterns of the file in order
+func (f *IgnoreFile) Rules() []Rule {
+	var rules []Rule
+
+	for _, line := range f.Lines {
+		if line.Rule != nil {
+			rules = append(rules, *line.Rule)
+		}
+	}
+
+	return rules
+}
+
+// Sections groups the lines by their section header
+func (f *IgnoreFile) Sections() []Section {
+	var sections []Section
+
+	current := Section{}
+
+	for _, line := range f.Lines {
+		if line.Kind == LineSection {
+			// Do not add an empty leading section
+			if current.Name != "" || len(current.Lines) != 0 {
+				sections = append(sections, current)
+			}
+
+			current = Section{Name: line.Section}
+		}
+
+		current.Lines = append(current.Lines, line)
+	}
+
+	if current.Name != "" || len(current.Lines) != 0 {
+		sections = append(sections, current)
+	}
+
+	return sections
+}
+
+// WriteTo writes the file to a writer, byte for byte
+// as it was parsed
+func (f *IgnoreFile) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, f.String())
+
+	return int64(n), err
+}
+
+func (f *IgnoreFile) String() string {
+	b := new(strings.Builder)
+
+	for _, line := range f.Lines {
+		b.WriteString(line.Text)
+		b.WriteString(line.Ending)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,135 @@
+package gitgen
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseIgnore_roundTrip(t *testing.T) {
+	tests := []struct {
+		name, text string
+	}{
+		{"Empty file", ""},
+		{"Unix line endings", "# Build\nbin/\n\n!bin/keep\n"},
+		{"Windows line endings", "# Build\r\nbin/\r\n\r\n"},
+		{"No final line ending", "*.o\n*.a"},
+		{"Mixed line endings and spaces", "a  \r\n\n  \t\nb\\ "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseIgnore(strings.NewReader(tt.text))
+
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			w := new(bytes.Buffer)
+			f.WriteTo(w)
+
+			if got := w.String(); got != tt.text {
+				t.Errorf("WriteTo() = %q, want %q", got, tt.text)
+			}
+		})
+	}
+}
+
+// Every embeded template should survive a round trip
+func TestParseIgnoreTemplate_roundTrip(t *testing.T) {
+	for _, name := range ignoreNames() {
+		f, err := ParseIgnoreTemplate(name)
+
+		if err != nil {
+			t.Fatalf("ParseIgnoreTemplate(%v) error: %v", name, err)
+		}
+
+		if got := f.String(); got != GetIgnoreText(name) {
+			t.Errorf("Template %v does not round trip", name)
+		}
+	}
+}
+
+func TestParseIgnoreTemplate_badKey(t *testing.T) {
+	if _, err := ParseIgnoreTemplate("WakandaForever"); err == nil {
+		t.Error("Expected an error, got nil")
+	}
+}
+
+func Test_parseLine(t *testing.T) {
+	tests := []struct {
+		name, text string
+		kind       LineKind
+		rule       *Rule
+	}{
+		{"Blank", "   ", LineBlank, nil},
+		{"Comment", "# Logs", LineComment, nil},
+		{"Indented hash is a pattern", " #x", LinePattern, &Rule{Pattern: " #x"}},
+		{"Escaped hash", `\#file`, LinePattern, &Rule{Pattern: `\#file`}},
+		{"Simple pattern", "*.log", LinePattern, &Rule{Pattern: "*.log"}},
+		{"Negated", "!keep.log", LinePattern, &Rule{Pattern: "keep.log", Negated: true}},
+		{"Escaped negation", `\!important`, LinePattern, &Rule{Pattern: `\!important`}},
+		{"Directory only", "node_modules/", LinePattern, &Rule{Pattern: "node_modules", DirOnly: true}},
+		{"Leading slash", "/build", LinePattern, &Rule{Pattern: "build", Anchored: true}},
+		{"Middle slash", "doc/*.txt", LinePattern, &Rule{Pattern: "doc/*.txt", Anchored: true}},
+		{"Everything", "!/out/", LinePattern, &Rule{Pattern: "out", Negated: true, DirOnly: true, Anchored: true}},
+		{"Trailing spaces", "*.o  ", LinePattern, &Rule{Pattern: "*.o"}},
+		{"Escaped trailing space", `a\ `, LinePattern, &Rule{Pattern: `a\ `}},
+		{"Only a slash", "/", LinePattern, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLine(tt.text)
+
+			if got.Kind != tt.kind {
+				t.Errorf("Kind = %v, want %v", got.Kind, tt.kind)
+			}
+
+			if !reflect.DeepEqual(got.Rule, tt.rule) {
+				t.Errorf("Rule = %+v, want %+v", got.Rule, tt.rule)
+			}
+		})
+	}
+}
+
+func TestIgnoreFile_Sections(t *testing.T) {
+	f, _ := ParseIgnore(strings.NewReader(fullCombined))
+
+	sections := f.Sections()
+
+	var names []string
+
+	for _, s := range sections {
+		names = append(names, s.Name)
+	}
+
+	if want := []string{"", "Ada", "CUDA"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Sections() names = %v, want %v", names, want)
+	}
+
+	// The CUDA section has its header and 6 patterns
+	if got := len(sections[2].Lines); got != 7 {
+		t.Errorf("Expected 7 lines in the CUDA section, got %d", got)
+	}
+}
+
+func TestIgnoreFile_Rules(t *testing.T) {
+	f, _ := ParseIgnore(strings.NewReader("# Comment\n*.o\n\n!keep.o\n"))
+
+	want := []Rule{
+		{Pattern: "*.o"},
+		{Pattern: "keep.o", Negated: true},
+	}
+
+	if got := f.Rules(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Rules() = %v, want %v", got, want)
+	}
+}
+
+// The result of combining Ada and CUDA
+var fullCombined = combine(
+	[]string{"Ada", "CUDA"},
+	[]string{fullAda, fullCUDA},
+)
@@ -2,24 +2,52 @@ package gitgen
 
 import (
 	"io"
+	"strings"
 )
 
 // GetIgnoreText returns the text of a git ignore
 // file as a string. The git ignore file is identified by the
-// name. All files come from Github
+// name. All files come from Github. If the template does
+// not exist, an empty string is returned
 func GetIgnoreText(key string) string {
-	// Get raw embeded bytes
-	raw, _ := asset("ignores/" + key + ".gitignore")
+	return defaultGenerator().GetIgnoreText(key)
+}
+
+// GetIgnoreText returns the text of a gitignore template of
+// the source, or an empty string if it does not exist
+func (g *Generator) GetIgnoreText(key string) string {
+	// Ignore the error to keep the old behaviour
+	txt, _ := g.IgnoreText(key)
+
+	return txt
+}
+
+// IgnoreText is like GetIgnoreText, but returns a *NotFoundError
+// wrapping ErrTemplateNotFound if the template does not exist
+func IgnoreText(key string) (string, error) {
+	return defaultGenerator().IgnoreText(key)
+}
+
+// IgnoreText returns the text of a gitignore template of the source
+func (g *Generator) IgnoreText(key string) (string, error) {
+	raw, err := g.ignoreAsset(key)
 
 	// Make them a string
-	return string(raw)
+	return string(raw), err
 }
 
 // WriteIgnore writes a .gitignore template to an
-// io.Writer. It can be a file, a http response, etc
+// io.Writer. It can be a file, a http response, etc.
+// If the template does not exist, the error wraps
+// ErrTemplateNotFound
 func WriteIgnore(key string, w io.Writer) (n int, err error) {
-	// get the data from the embeded file
-	data, err := asset("ignores/" + key + ".gitignore")
+	return defaultGenerator().WriteIgnore(key, w)
+}
+
+// WriteIgnore writes a gitignore template of the source to a writer
+func (g *Generator) WriteIgnore(key string, w io.Writer) (n int, err error) {
+	// get the data from the source
+	data, err := g.ignoreAsset(key)
 
 	if err != nil {
 		return
@@ -29,7 +57,54 @@ func WriteIgnore(key string, w io.Writer) (n int, err error) {
 }
 
 // ListIgnores returns a slice of strings containing
-// the names of all available git ignore templates
+// the names of all available git ignore templates, without
+// the extension and sorted ignoring the case. The names
+// can be used directly as keys. The templates added with
+// AddTemplateFS are included
 func ListIgnores() []string {
-	return listAssets("ignores")
+	return defaultGenerator().ListIgnores()
+}
+
+// ListIgnores returns the names of the gitignore templates of the source
+func (g *Generator) ListIgnores() []string {
+	return sortNames(g.ignoreNames())
+}
+
+// IgnoreTemplates is like ListIgnores, but also tells
+// where each template comes from
+func IgnoreTemplates() []Template {
+	return defaultGenerator().IgnoreTemplates()
+}
+
+// IgnoreTemplates returns the gitignore templates of the source
+func (g *Generator) IgnoreTemplates() []Template {
+	names := sortNames(g.ignoreNames())
+
+	list := make([]Template, len(names))
+
+	for i, name := range names {
+		path := g.ignorePath(name)
+
+		category := CategoryProject
+
+		if strings.HasPrefix(path, globalFolder+"/") {
+			category = CategoryGlobal
+		}
+
+		list[i] = Template{name, sourceName(g.source(), path), category}
+	}
+
+	return list
+}
+
+// Get the raw bytes of a gitignore template. The key
+// is case insensitive and can be an alias
+func (g *Generator) ignoreAsset(key string) ([]byte, error) {
+	data, err := g.asset(g.ignorePath(g.resolveIgnore(key)))
+
+	if err != nil {
+		return nil, wrapAssetErr(err, kindIgnore, key, g.ignoreNames)
+	}
+
+	return data, nil
 }
@@ -39,6 +39,31 @@ func TestGetIgnoreText(t *testing.T) {
 	}
 }
 
+func TestIgnoreText(t *testing.T) {
+	tests := []struct {
+		name, key, want string
+		wantErr         bool
+	}{
+		{"Ada .gitignore", "Ada", fullAda, false},
+		{"CUDA .gitignore", "CUDA", fullCUDA, false},
+		{"Case insensitive key", "cuda", fullCUDA, false},
+		{"Bad Key", "BadKey", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IgnoreText(tt.key)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IgnoreText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("IgnoreText() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestWriteIgnore(t *testing.T) {
 	tests := []struct {
 		name, key, want string
@@ -70,7 +95,19 @@ func TestWriteIgnore(t *testing.T) {
 func TestListIgnores(t *testing.T) {
 	ignores := ListIgnores()
 
-	if got := len(ignores); got != 127 {
-		t.Error("Expected 127 git ignore files, got ", got)
+	if got := len(ignores); got != 134 {
+		t.Error("Expected 134 git ignore files, got ", got)
+	}
+
+	// The names are keys: no extension, and sorted ignoring the case
+	if ignores[0] != "Actionscript" || ignores[len(ignores)-1] != "Zephir" {
+		t.Errorf("Unexpected first and last names: %v, %v",
+			ignores[0], ignores[len(ignores)-1])
+	}
+
+	for _, name := range ignores {
+		if _, err := IgnoreText(name); err != nil {
+			t.Errorf("'%v' is not a valid key: %v", name, err)
+		}
 	}
 }
@@ -0,0 +1,133 @@
+package gitgen
+
+import (
+	"encoding/json"
+)
+
+// License has the metadata of a license template, modeled
+// after choosealicense.com
+type License struct {
+	// Key is the name of the template, used by GetLicenseText
+	Key string `json:"key"`
+
+	// SPDX is the SPDX identifier, like Apache-2.0
+	SPDX string `json:"spdx_id"`
+
+	// HeaderSPDX is the SPDX identifier of the headers of the source
+	// files when it is not SPDX, like GPL-3.0-or-later for the GNU
+	// notices that allow any later version. It can be empty
+	HeaderSPDX string `json:"header_spdx_id,omitempty"`
+
+	// Name is the full name of the license
+	Name string `json:"name"`
+
+	// Nickname is a common short name, like GNU GPLv3. It can be empty
+	Nickname string `json:"nickname,omitempty"`
+
+	Description string `json:"description"`
+
+	// Permissions are the things the license allows,
+	// like commercial-use or patent-use
+	Permissions []string `json:"permissions"`
+
+	// Conditions are the things the license requires,
+	// like include-copyright or same-license
+	Conditions []string `json:"conditions"`
+
+	// Limitations are the things the license does
+	// not provide, like liability or warranty
+	Limitations []string `json:"limitations"`
+
+	// OSIApproved tells if the Open Source Initiative approved it
+	OSIApproved bool `json:"osi_approved"`
+
+	// FSFLibre tells if the Free Software Foundation
+	// considers it a free license
+	FSFLibre bool `json:"fsf_libre"`
+
+	// Placeholders are the fields of the text meant to be
+	// replaced, like [year] or [fullname], the same ones as
+	// Placeholders. They are empty for the licenses written
+	// verbatim, as their copyright goes in the notice
+	Placeholders []string `json:"placeholders"`
+}
+
+// Category tells the kind of license: "copyleft" if changes
+// must be published, "public-domain" if it has no conditions
+// at all and "permissive" otherwise
+func (l License) Category() string {
+	switch {
+	case hasPrefix(l.Conditions, "disclose-source"):
+		return "copyleft"
+	case len(l.Conditions) == 0:
+		return "public-domain"
+	}
+
+	return "permissive"
+}
+
+// The metadata of every license, in assets/licenseinfo.json
+var licenseInfo = loadLicenseInfo()
+
+func loadLicenseInfo() []License {
+	data, err := asset("licenseinfo.json")
+
+	if err != nil {
+		return nil
+	}
+
+	var licenses []License
+
+	// A broken file is caught by the tests
+	json.Unmarshal(data, &licenses)
+
+	return licenses
+}
+
+// LicenseInfo returns the metadata of a license. The key is case
+// insensitive and can be an alias. If the license does not exist
+// the error wraps ErrTemplateNotFound
+func LicenseInfo(key string) (License, error) {
+	return defaultGenerator().LicenseInfo(key)
+}
+
+// LicenseInfo returns the metadata of a license of the source.
+// The metadata is the embeded one, so the licenses of the source
+// that are not embeded have none
+func (g *Generator) LicenseInfo(key string) (License, error) {
+	names := g.licenseNames()
+	name := resolve(key, names, licenseAliases)
+
+	for _, lic := range g.Licenses() {
+		if lic.Key == name {
+			return lic, nil
+		}
+	}
+
+	return License{}, notFound(kindLicense, key, names)
+}
+
+// Licenses returns the metadata of all the licenses, sorted by key
+func Licenses() []License {
+	return defaultGenerator().Licenses()
+}
+
+// Licenses returns the metadata of the licenses of the source
+// that have one, sorted by key
+func (g *Generator) Licenses() []License {
+	present := make(map[string]bool)
+
+	for _, name := range g.licenseNames() {
+		present[name] = true
+	}
+
+	var licenses []License
+
+	for _, lic := range licenseInfo {
+		if present[lic.Key] {
+			licenses = append(licenses, lic)
+		}
+	}
+
+	return licenses
+}
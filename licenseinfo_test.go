@@ -0,0 +1,119 @@
+package gitgen
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestLicenseInfo(t *testing.T) {
+	tests := []struct {
+		name, key, wantSPDX string
+	}{
+		{"By key", "apache-2.0", "Apache-2.0"},
+		{"Case insensitive", "MIT", "MIT"},
+		{"Alias", "gplv3", "GPL-3.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LicenseInfo(tt.key)
+
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			if got.SPDX != tt.wantSPDX {
+				t.Errorf("LicenseInfo().SPDX = %v, want %v", got.SPDX, tt.wantSPDX)
+			}
+		})
+	}
+
+	if _, err := LicenseInfo("lol"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+	}
+}
+
+func TestLicenseInfo_apache(t *testing.T) {
+	got, _ := LicenseInfo("apache-2.0")
+
+	want := License{
+		Key:          "apache-2.0",
+		SPDX:         "Apache-2.0",
+		Name:         "Apache License 2.0",
+		Description:  got.Description,
+		Permissions:  []string{"commercial-use", "modifications", "distribution", "patent-use", "private-use"},
+		Conditions:   []string{"include-copyright", "document-changes"},
+		Limitations:  []string{"trademark-use", "liability", "warranty"},
+		OSIApproved:  true,
+		FSFLibre:     true,
+		Placeholders: []string{"[yyyy]", "[name of copyright owner]"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LicenseInfo() = %+v, want %+v", got, want)
+	}
+}
+
+// Every license template has metadata and the other way around
+func TestLicenses(t *testing.T) {
+	var keys []string
+
+	for _, lic := range Licenses() {
+		keys = append(keys, lic.Key)
+
+		txt, err := LicenseText(lic.Key)
+
+		if err != nil {
+			t.Errorf("There is metadata for %v, but no template", lic.Key)
+			continue
+		}
+
+		if lic.SPDX == "" || lic.Name == "" || lic.Description == "" {
+			t.Errorf("Incomplete metadata for %v", lic.Key)
+		}
+
+		for _, p := range lic.Placeholders {
+			if !strings.Contains(txt, p) {
+				t.Errorf("%v does not contain the placeholder %v", lic.Key, p)
+			}
+		}
+
+		// The metadata agrees with Placeholders
+		if want := Placeholders(lic.Key); strings.Join(lic.Placeholders, ",") != strings.Join(want, ",") {
+			t.Errorf("The placeholders of %v are %v, but Placeholders() = %v", lic.Key, lic.Placeholders, want)
+		}
+	}
+
+	names := licenseNames()
+	sort.Strings(names)
+
+	if !reflect.DeepEqual(keys, names) {
+		t.Errorf("Licenses() keys = %v, want %v", keys, names)
+	}
+}
+
+func TestLicense_Category(t *testing.T) {
+	tests := []struct {
+		key, want string
+	}{
+		{"mit", "permissive"},
+		{"apache-2.0", "permissive"},
+		{"gpl-3.0", "copyleft"},
+		{"mpl-2.0", "copyleft"},
+		{"unlicense", "public-domain"},
+		{"cc0-1.0", "public-domain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			lic, _ := LicenseInfo(tt.key)
+
+			if got := lic.Category(); got != tt.want {
+				t.Errorf("Category() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,25 +1,52 @@
 package gitgen
 
 import (
+	"fmt"
 	"io"
 	"strings"
 )
 
 // GetLicenseText returns the text of a license
-// The key is its SPDX identifier
+// The key is its SPDX identifier. If the license
+// does not exist, an empty string is returned
 func GetLicenseText(key string) string {
-	// Get raw embeded bytes
-	raw, _ := asset("licenses/" + key + ".txt")
+	return defaultGenerator().GetLicenseText(key)
+}
+
+// GetLicenseText returns the text of a license of the
+// source, or an empty string if it does not exist
+func (g *Generator) GetLicenseText(key string) string {
+	// Ignore the error to keep the old behaviour
+	txt, _ := g.LicenseText(key)
+
+	return txt
+}
+
+// LicenseText is like GetLicenseText, but returns a *NotFoundError
+// wrapping ErrTemplateNotFound if the license does not exist
+func LicenseText(key string) (string, error) {
+	return defaultGenerator().LicenseText(key)
+}
+
+// LicenseText returns the text of a license of the source
+func (g *Generator) LicenseText(key string) (string, error) {
+	raw, err := g.licenseAsset(key)
 
 	// Make them a string
-	return string(raw)
+	return string(raw), err
 }
 
 // WriteLicense writes a license to a writer. It can be a file, a
-// http response, etc
+// http response, etc. If the license does not exist, the error
+// wraps ErrTemplateNotFound
 func WriteLicense(key string, w io.Writer) (n int, err error) {
-	// get the data from the embeded file
-	data, err := asset("licenses/" + key + ".txt")
+	return defaultGenerator().WriteLicense(key, w)
+}
+
+// WriteLicense writes a license of the source to a writer
+func (g *Generator) WriteLicense(key string, w io.Writer) (n int, err error) {
+	// get the data from the source
+	data, err := g.licenseAsset(key)
 
 	if err != nil {
 		return
@@ -30,9 +57,21 @@ func WriteLicense(key string, w io.Writer) (n int, err error) {
 
 // GetLicWithParams gets a license and adds the fullname and the
 // year parameters to the license. Not all the licenses
-// allow these fields
+// allow these fields, and the ones with their own
+// notice, like the GPL, are returned verbatim without
+// them. Their copyright goes in the notice, see Notice
 func GetLicWithParams(key, fullname, year string) string {
-	txt := GetLicenseText(key)
+	return defaultGenerator().GetLicWithParams(key, fullname, year)
+}
+
+// GetLicWithParams gets a license of the source
+// with the fullname and the year parameters
+func (g *Generator) GetLicWithParams(key, fullname, year string) string {
+	txt := g.GetLicenseText(key)
+
+	if g.HasNotice(key) {
+		return txt
+	}
 
 	// This is a different function for testability
 	return replaceString(txt, fullname, year)
@@ -46,10 +85,36 @@ func replaceString(fullText, fullname, year string) string {
 	return r.Replace(fullText)
 }
 
+// WriteLicWithParams is like GetLicWithParams, but writes the
+// license to an io.Writer. If the license does not exist, the
+// error wraps ErrTemplateNotFound. The licenses with their own
+// notice, like the GPL, fail with ErrCopyrightInNotice if the
+// fullname or the year are given, as they would be lost, and
+// nothing is written. Use Notice for their copyright
 func WriteLicWithParams(key, fullname, year string,
+	w io.Writer) (int, error) {
+	return defaultGenerator().WriteLicWithParams(key, fullname, year, w)
+}
+
+// WriteLicWithParams writes a license of the source to a
+// writer with the name and the year filled in
+func (g *Generator) WriteLicWithParams(key, fullname, year string,
 	w io.Writer) (int, error) {
 	// Get the text
-	txt := GetLicenseText(key)
+	txt, err := g.LicenseText(key)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// The copyright of these goes in the notice
+	if g.HasNotice(key) {
+		if fullname != "" || year != "" {
+			return 0, fmt.Errorf("license '%s': %w", g.resolveLicense(key), ErrCopyrightInNotice)
+		}
+
+		return io.WriteString(w, txt)
+	}
 
 	// Call the helper function
 	return replaceWrite(txt, fullname, year, w)
@@ -84,7 +149,37 @@ func makeLicenseReplacer(fullname, year string) *strings.Replacer {
 }
 
 // ListLicenses returns a slice of strings containing
-// the names of all available license templates
+// the keys of all available license templates, without
+// the extension and sorted, including the ones added
+// with AddTemplateFS
 func ListLicenses() []string {
-	return listAssets("licenses")
+	return defaultGenerator().ListLicenses()
+}
+
+// ListLicenses returns the keys of the licenses of the source
+func (g *Generator) ListLicenses() []string {
+	return sortNames(g.licenseNames())
+}
+
+// LicenseTemplates is like ListLicenses, but also tells
+// where each license comes from
+func LicenseTemplates() []Template {
+	return defaultGenerator().LicenseTemplates()
+}
+
+// LicenseTemplates returns the licenses of the source
+func (g *Generator) LicenseTemplates() []Template {
+	return g.templates("licenses", ".txt")
+}
+
+// Get the raw bytes of a license template. The key
+// is case insensitive and can be an alias
+func (g *Generator) licenseAsset(key string) ([]byte, error) {
+	data, err := g.asset("licenses/" + g.resolveLicense(key) + ".txt")
+
+	if err != nil {
+		return nil, wrapAssetErr(err, kindLicense, key, g.licenseNames)
+	}
+
+	return data, nil
 }
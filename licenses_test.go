@@ -3,6 +3,7 @@ package gitgen
 import (
 	"bytes"
 	_ "embed"
+	"errors"
 	"strings"
 	"testing"
 )
@@ -34,6 +35,32 @@ func TestGetLicenseText(t *testing.T) {
 	}
 }
 
+func TestLicenseText(t *testing.T) {
+	tests := []struct {
+		name, key, want string
+		wantErr         bool
+	}{
+		{"MIT License", "mit", fullMIT, false},
+		{"Boost Software License", "bsl-1.0", fullBSL, false},
+		{"Alias", "boost", fullBSL, false},
+		{"Does not exists", "lol", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LicenseText(tt.key)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LicenseText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("LicenseText() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestWriteLicense(t *testing.T) {
 	tests := []struct {
 		name, key, want string
@@ -206,10 +233,10 @@ func TestGetLicWithParams(t *testing.T) {
 		},
 
 		{
-			"GPL test (uses <>)",
+			"GPL test (verbatim, the copyright goes in the notice)",
 			args{"gpl-3.0", "eacp", "2021"},
 			// Notice the 2 spaces
-			"Copyright (C) 2021  eacp",
+			"Copyright (C) <year>  <name of author>",
 		},
 	}
 	for _, tt := range tests {
@@ -255,13 +282,6 @@ func TestWriteLicWithParams(t *testing.T) {
 			args{"apache-2.0", "eacp", "2021"},
 			"Copyright 2021 eacp",
 		},
-
-		{
-			"GPL test (uses <>)",
-			args{"gpl-3.0", "eacp", "2021"},
-			// Notice the 2 spaces
-			"Copyright (C) 2021  eacp",
-		},
 	}
 	for _, tt := range tests {
 
@@ -285,10 +305,39 @@ func TestWriteLicWithParams(t *testing.T) {
 	}
 }
 
+func TestWriteLicWithParams_notice(t *testing.T) {
+	w := new(bytes.Buffer)
+
+	// The copyright of the GPL goes in its notice
+	if _, err := WriteLicWithParams("GPL-3.0", "eacp", "2021", w); !errors.Is(err, ErrCopyrightInNotice) {
+		t.Errorf("Expected ErrCopyrightInNotice, got %v", err)
+	}
+
+	if w.Len() != 0 {
+		t.Error("Nothing should be written on error")
+	}
+
+	// Without a copyright it is written verbatim
+	if _, err := WriteLicWithParams("gpl-3.0", "", "", w); err != nil || w.String() != GetLicenseText("gpl-3.0") {
+		t.Errorf("Expected the GPL verbatim, got %v", err)
+	}
+}
+
 func TestListLicenses(t *testing.T) {
 	licenses := ListLicenses()
 
 	if got := len(licenses); got != 13 {
 		t.Error("Expected 13 license files, got ", got)
 	}
+
+	if licenses[0] != "agpl-3.0" || licenses[len(licenses)-1] != "unlicense" {
+		t.Errorf("Unexpected first and last keys: %v, %v",
+			licenses[0], licenses[len(licenses)-1])
+	}
+
+	for _, key := range licenses {
+		if _, err := LicenseText(key); err != nil {
+			t.Errorf("'%v' is not a valid key: %v", key, err)
+		}
+	}
 }
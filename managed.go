@@ -0,0 +1,263 @@
+package gitgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrMalformedBlock is returned when the markers of a managed
+// block do not match, like a block that is never closed, a
+// closing marker without a block or a block inside another
+var ErrMalformedBlock = errors.New("malformed managed block")
+
+// Markers around a managed block. The name of the template
+// goes between the prefix and the suffix, and the markers
+// start at the first column
+const (
+	blockStartPrefix = "# >>> gitgen "
+	blockStartSuffix = " >>>"
+	blockEndPrefix   = "# <<< gitgen "
+	blockEndSuffix   = " <<<"
+)
+
+// WriteManagedIgnores writes gitignore templates to a writer, each
+// one between marker comments like
+//
+//	# >>> gitgen Node >>>
+//	...
+//	# <<< gitgen Node <<<
+//
+// so UpdateManagedIgnores can replace them later with newer
+// versions of the templates. The templates are written as they
+// are, without removing repeated patterns. If any template does
+// not exist nothing is written and the error wraps
+// ErrTemplateNotFound
+func WriteManagedIgnores(keys []string, w io.Writer) (n int, err error) {
+	return defaultGenerator().WriteManagedIgnores(keys, w)
+}
+
+// WriteManagedIgnores writes gitignore templates of the
+// source to a writer, each one in a managed block
+func (g *Generator) WriteManagedIgnores(keys []string, w io.Writer) (n int, err error) {
+	names, texts, err := g.loadIgnores(keys)
+
+	if err != nil {
+		return 0, err
+	}
+
+	b := new(strings.Builder)
+
+	for i, name := range names {
+		// Separate the blocks
+		if i != 0 {
+			b.WriteString("\n")
+		}
+
+		b.WriteString(managedBlock(name, texts[i]))
+	}
+
+	return io.WriteString(w, b.String())
+}
+
+// UpdateManagedIgnores reads a .gitignore and writes it to a writer
+// with the managed blocks replaced by the templates embeded in this
+// version of the package. Everything outside of the blocks is kept
+// byte for byte. It returns the names of the blocks that changed.
+// If the markers do not match the error wraps ErrMalformedBlock, and
+// if its template does not exist the error wraps
+// ErrTemplateNotFound. In both cases nothing is written
+func UpdateManagedIgnores(r io.Reader, w io.Writer) (updated []string, err error) {
+	return defaultGenerator().UpdateManagedIgnores(r, w)
+}
+
+// UpdateManagedIgnores replaces the managed blocks of a
+// .gitignore with the templates of the source
+func (g *Generator) UpdateManagedIgnores(r io.Reader, w io.Writer) (updated []string, err error) {
+	data, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lines := parseIgnoreBytes(data).Lines
+
+	b := new(strings.Builder)
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if name, ok := blockName(line.Text, blockEndPrefix, blockEndSuffix); ok {
+			return nil, fmt.Errorf("line %d: block %s is closed but never opened: %w",
+				line.Number, name, ErrMalformedBlock)
+		}
+
+		name, ok := blockName(line.Text, blockStartPrefix, blockStartSuffix)
+
+		if !ok {
+			// Not managed, keep it
+			b.WriteString(line.Text + line.Ending)
+			continue
+		}
+
+		end, err := closingLine(lines, i, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		txt, err := g.IgnoreText(name)
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.Number, err)
+		}
+
+		// Compare with the current content
+		old := new(strings.Builder)
+
+		for _, l := range lines[i : end+1] {
+			old.WriteString(l.Text + l.Ending)
+		}
+
+		block := managedBlock(name, txt)
+
+		// Keep the original line ending of the closing marker
+		if lines[end].Ending == "" {
+			block = strings.TrimSuffix(block, "\n")
+		}
+
+		if block != old.String() {
+			updated = append(updated, name)
+		}
+
+		b.WriteString(block)
+
+		i = end
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// MergeManagedIgnores adds gitignore templates in managed blocks
+// to an existing .gitignore, read from a reader, and writes the
+// result to a writer. The blocks already in the file are updated
+// in place, like UpdateManagedIgnores does, and the templates
+// without a block are appended at the end, so running it again
+// with the same templates changes nothing. It returns the
+// templates added and the blocks that changed. The errors are
+// the ones of UpdateManagedIgnores, and nothing is written
+// when there is one
+func MergeManagedIgnores(existing io.Reader, keys []string, w io.Writer) (added, updated []string, err error) {
+	return defaultGenerator().MergeManagedIgnores(existing, keys, w)
+}
+
+// MergeManagedIgnores adds gitignore templates of the source
+// in managed blocks to an existing .gitignore
+func (g *Generator) MergeManagedIgnores(existing io.Reader, keys []string, w io.Writer) (added, updated []string, err error) {
+	names, texts, err := g.loadIgnores(keys)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := io.ReadAll(existing)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := new(bytes.Buffer)
+
+	if updated, err = g.UpdateManagedIgnores(bytes.NewReader(data), out); err != nil {
+		return nil, nil, err
+	}
+
+	// The templates that already have a block
+	present := make(map[string]bool)
+
+	for _, line := range parseIgnoreBytes(data).Lines {
+		if name, ok := blockName(line.Text, blockStartPrefix, blockStartSuffix); ok {
+			present[name] = true
+		}
+	}
+
+	for i, name := range names {
+		if present[name] {
+			continue
+		}
+
+		// Do not glue the block to the last line
+		if out.Len() != 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+			out.WriteString("\n")
+		}
+
+		if out.Len() != 0 {
+			out.WriteString("\n")
+		}
+
+		out.WriteString(managedBlock(name, texts[i]))
+		added = append(added, name)
+	}
+
+	if _, err := w.Write(out.Bytes()); err != nil {
+		return nil, nil, err
+	}
+
+	return added, updated, nil
+}
+
+// Wrap a template in markers
+func managedBlock(name, text string) string {
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
+	return blockStartPrefix + name + blockStartSuffix + "\n" +
+		text +
+		blockEndPrefix + name + blockEndSuffix + "\n"
+}
+
+// Get the template name from a marker line. Indented
+// markers are kept as plain comments
+func blockName(text, prefix, suffix string) (string, bool) {
+	text = strings.TrimRight(text, " \t")
+
+	if len(text) < len(prefix)+len(suffix) ||
+		!strings.HasPrefix(text, prefix) || !strings.HasSuffix(text, suffix) {
+		return "", false
+	}
+
+	name := strings.TrimSpace(text[len(prefix) : len(text)-len(suffix)])
+
+	return name, name != ""
+}
+
+// Find the line that closes the block opened at start
+func closingLine(lines []Line, start int, name string) (int, error) {
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+
+		if inner, ok := blockName(line.Text, blockStartPrefix, blockStartSuffix); ok {
+			return -1, fmt.Errorf("line %d: block %s starts inside block %s: %w",
+				line.Number, inner, name, ErrMalformedBlock)
+		}
+
+		if end, ok := blockName(line.Text, blockEndPrefix, blockEndSuffix); ok {
+			if end != name {
+				return -1, fmt.Errorf("line %d: block %s is closed as %s: %w",
+					line.Number, name, end, ErrMalformedBlock)
+			}
+
+			return i, nil
+		}
+	}
+
+	return -1, fmt.Errorf("line %d: block %s is not closed: %w",
+		lines[start].Number, name, ErrMalformedBlock)
+}
@@ -0,0 +1,186 @@
+package gitgen
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteManagedIgnores(t *testing.T) {
+	w := new(bytes.Buffer)
+
+	if _, err := WriteManagedIgnores([]string{"ada", "CUDA"}, w); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	want := "# >>> gitgen Ada >>>\n" + fullAda + "# <<< gitgen Ada <<<\n" +
+		"\n" +
+		"# >>> gitgen CUDA >>>\n" + fullCUDA + "# <<< gitgen CUDA <<<\n"
+
+	if got := w.String(); got != want {
+		t.Errorf("WriteManagedIgnores() = %q, want %q", got, want)
+	}
+}
+
+func TestUpdateManagedIgnores(t *testing.T) {
+	// An outdated Ada block between user rules
+	text := "# Ours\r\nsecret.txt\r\n" +
+		"# >>> gitgen Ada >>>\n*.old\n# <<< gitgen Ada <<<\n" +
+		"# >>> gitgen CUDA >>>\n" + fullCUDA + "# <<< gitgen CUDA <<<\n" +
+		"local/"
+
+	w := new(bytes.Buffer)
+
+	updated, err := UpdateManagedIgnores(strings.NewReader(text), w)
+
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	if want := []string{"Ada"}; !reflect.DeepEqual(updated, want) {
+		t.Errorf("Updated blocks = %v, want %v", updated, want)
+	}
+
+	want := "# Ours\r\nsecret.txt\r\n" +
+		"# >>> gitgen Ada >>>\n" + fullAda + "# <<< gitgen Ada <<<\n" +
+		"# >>> gitgen CUDA >>>\n" + fullCUDA + "# <<< gitgen CUDA <<<\n" +
+		"local/"
+
+	if got := w.String(); got != want {
+		t.Errorf("UpdateManagedIgnores() = %q, want %q", got, want)
+	}
+
+	t.Run("Up to date", func(t *testing.T) {
+		again := new(bytes.Buffer)
+
+		updated, _ := UpdateManagedIgnores(strings.NewReader(want), again)
+
+		if len(updated) != 0 || again.String() != want {
+			t.Errorf("Expected no changes, got %v", updated)
+		}
+	})
+}
+
+func TestUpdateManagedIgnores_errors(t *testing.T) {
+	tests := []struct {
+		name, text string
+		want       error
+	}{
+		{
+			"Not closed",
+			"# >>> gitgen Ada >>>\n*.o\n",
+			ErrMalformedBlock,
+		},
+		{
+			"Closed with another name",
+			"# >>> gitgen Ada >>>\n*.o\n# <<< gitgen C <<<\n",
+			ErrMalformedBlock,
+		},
+		{
+			"Closed without a block",
+			"*.o\n# <<< gitgen Ada <<<\n",
+			ErrMalformedBlock,
+		},
+		{
+			"Nested blocks",
+			"# >>> gitgen Ada >>>\n# >>> gitgen C >>>\n# <<< gitgen C <<<\n# <<< gitgen Ada <<<\n",
+			ErrMalformedBlock,
+		},
+		{
+			"Unknown template",
+			"# >>> gitgen WakandaForever >>>\n# <<< gitgen WakandaForever <<<\n",
+			ErrTemplateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+
+			_, err := UpdateManagedIgnores(strings.NewReader(tt.text), w)
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+
+			if w.Len() != 0 {
+				t.Error("Nothing should be written on error")
+			}
+		})
+	}
+}
+
+func TestMergeManagedIgnores(t *testing.T) {
+	// An outdated Ada block after a rule without a newline
+	text := "# >>> gitgen Ada >>>\n*.old\n# <<< gitgen Ada <<<\nsecret.txt"
+
+	w := new(bytes.Buffer)
+
+	added, updated, err := MergeManagedIgnores(strings.NewReader(text), []string{"ada", "CUDA"}, w)
+
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	if !reflect.DeepEqual(added, []string{"CUDA"}) || !reflect.DeepEqual(updated, []string{"Ada"}) {
+		t.Errorf("Added %v and updated %v, want [CUDA] and [Ada]", added, updated)
+	}
+
+	want := "# >>> gitgen Ada >>>\n" + fullAda + "# <<< gitgen Ada <<<\nsecret.txt\n" +
+		"\n" +
+		"# >>> gitgen CUDA >>>\n" + fullCUDA + "# <<< gitgen CUDA <<<\n"
+
+	if got := w.String(); got != want {
+		t.Errorf("MergeManagedIgnores() = %q, want %q", got, want)
+	}
+
+	t.Run("Idempotent", func(t *testing.T) {
+		again := new(bytes.Buffer)
+
+		added, updated, _ := MergeManagedIgnores(strings.NewReader(want), []string{"CUDA", "Ada"}, again)
+
+		if len(added) != 0 || len(updated) != 0 || again.String() != want {
+			t.Errorf("Second merge added %v, updated %v and changed the file", added, updated)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		bad := new(bytes.Buffer)
+
+		_, _, err := MergeManagedIgnores(strings.NewReader("# >>> gitgen Ada >>>\n"), []string{"CUDA"}, bad)
+
+		if !errors.Is(err, ErrMalformedBlock) || bad.Len() != 0 {
+			t.Errorf("Expected ErrMalformedBlock and no output, got %v", err)
+		}
+
+		if _, _, err := MergeManagedIgnores(strings.NewReader(""), []string{"Nope"}, bad); !errors.Is(err, ErrTemplateNotFound) {
+			t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+		}
+	})
+}
+
+func Test_blockName(t *testing.T) {
+	tests := []struct {
+		text, want string
+		ok         bool
+	}{
+		{"# >>> gitgen Node >>>", "Node", true},
+		{"# >>> gitgen C++ >>>  ", "C++", true},
+		{"  # >>> gitgen C++ >>>", "", false},
+		{"# >>> gitgen >>>", "", false},
+		{"# >>> other Node >>>", "", false},
+		{"*.o", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := blockName(tt.text, blockStartPrefix, blockStartSuffix)
+
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("blockName() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package gitgen
+
+import (
+	"path"
+	"strings"
+)
+
+// Matcher tells if a path would be ignored by a set of gitignore
+// files, following the same rules as git: wildcards, **, negation,
+// directory only patterns, anchoring and last match wins. All the
+// files are treated as if they were in the root of the repository
+type Matcher struct {
+	rules []sourceRule
+
+	// Where AddTemplate reads the templates from.
+	// If it is nil the package functions are used
+	generator *Generator
+}
+
+// A rule with the file and the line it comes from
+type sourceRule struct {
+	source string
+	line   Line
+}
+
+// Match is the rule that decided if a path is ignored
+type Match struct {
+	// Source is the template name or the file the rule comes from
+	Source string
+
+	// Line is the line number of the rule in its source
+	Line int
+
+	// Text is the pattern as it was written
+	Text string
+
+	Rule Rule
+}
+
+// Ignored tells if the path is ignored. A negated
+// rule means the path is not ignored, and so does
+// a zero Match, which has no rule
+func (m Match) Ignored() bool {
+	return m.Rule.Pattern != "" && !m.Rule.Negated
+}
+
+// NewMatcher creates a matcher with no rules
+func NewMatcher() *Matcher {
+	return new(Matcher)
+}
+
+// NewTemplateMatcher creates a matcher with the rules of
+// several embeded gitignore templates, in order
+func NewTemplateMatcher(keys ...string) (*Matcher, error) {
+	return defaultGenerator().NewTemplateMatcher(keys...)
+}
+
+// NewTemplateMatcher creates a matcher with the rules of several
+// gitignore templates of the source. Its AddTemplate uses the
+// source too
+func (g *Generator) NewTemplateMatcher(keys ...string) (*Matcher, error) {
+	m := &Matcher{generator: g}
+
+	for _, key := range keys {
+		if err := m.AddTemplate(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Add appends the rules of a gitignore file. The source
+// is used to report where a match comes from
+func (m *Matcher) Add(source string, f *IgnoreFile) {
+	for _, line := range f.Lines {
+		if line.Rule != nil {
+			m.rules = append(m.rules, sourceRule{source, line})
+		}
+	}
+}
+
+// AddTemplate appends the rules of an embeded gitignore template.
+// If it does not exist the error wraps ErrTemplateNotFound
+func (m *Matcher) AddTemplate(key string) error {
+	g := m.generator
+
+	if g == nil {
+		g = defaultGenerator()
+	}
+
+	f, err := g.ParseIgnoreTemplate(key)
+
+	if err != nil {
+		return err
+	}
+
+	m.Add(g.resolveIgnore(key), f)
+
+	return nil
+}
+
+// Match returns the rule that decides if a path is ignored, and
+// false if no rule matches it. The path is relative to the root of
+// the repository and uses forward slashes. If a parent directory is
+// ignored, the path is ignored by the same rule, as files inside
+// an ignored directory can not be included again
+func (m *Matcher) Match(name string, isDir bool) (Match, bool) {
+	name = cleanMatchPath(name)
+
+	if name == "" {
+		return Match{}, false
+	}
+
+	// Check the parent directories first
+	parts := strings.Split(name, "/")
+
+	for i := 1; i < len(parts); i++ {
+		dir := strings.Join(parts[:i], "/")
+
+		if match, ok := m.lastMatch(dir, true); ok && match.Ignored() {
+			return match, true
+		}
+	}
+
+	return m.lastMatch(name, isDir)
+}
+
+// Ignored tells if a path would be ignored
+func (m *Matcher) Ignored(name string, isDir bool) bool {
+	match, ok := m.Match(name, isDir)
+
+	return ok && match.Ignored()
+}
+
+// Find the last rule matching the path, without
+// looking at the parent directories
+func (m *Matcher) lastMatch(name string, isDir bool) (Match, bool) {
+	for i := len(m.rules) - 1; i >= 0; i-- {
+		r := m.rules[i]
+
+		if matchRule(r.line.Rule, name, isDir) {
+			return Match{
+				Source: r.source,
+				Line:   r.line.Number,
+				Text:   r.line.Text,
+				Rule:   *r.line.Rule,
+			}, true
+		}
+	}
+
+	return Match{}, false
+}
+
+// Remove the leading ./ and /, and the trailing /
+func cleanMatchPath(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+
+	for strings.HasPrefix(name, "./") {
+		name = name[2:]
+	}
+
+	return strings.Trim(path.Clean("/"+name), "/")
+}
+
+// Check if a single rule matches a path
+func matchRule(rule *Rule, name string, isDir bool) bool {
+	if rule.DirOnly && !isDir {
+		return false
+	}
+
+	// Patterns without a slash match the name at any level
+	if !rule.Anchored {
+		return wildmatch(rule.Pattern, path.Base(name))
+	}
+
+	return wildmatch(rule.Pattern, name)
+}
+
+// Match a gitignore glob against a path. * and ? do not match /,
+// ** matches any number of directories and \ escapes a character
+func wildmatch(pattern, name string) bool {
+	return wildmatchFrom(pattern, name, true)
+}
+
+// Like wildmatch, segStart tells if the pattern is at the
+// start of a path segment, which is needed to handle **
+func wildmatchFrom(pattern, name string, segStart bool) bool {
+	for ; len(pattern) != 0; segStart = false {
+		switch c := pattern[0]; c {
+		case '*':
+			// A ** between slashes matches any number of directories
+			if segStart && strings.HasPrefix(pattern, "**") &&
+				(len(pattern) == 2 || pattern[2] == '/') {
+				rest := pattern[2:]
+
+				// A trailing ** matches everything
+				if rest == "" {
+					return true
+				}
+
+				rest = rest[1:]
+
+				// Try at every directory level, including none
+				for {
+					if wildmatchFrom(rest, name, true) {
+						return true
+					}
+
+					i := strings.IndexByte(name, '/')
+
+					if i < 0 {
+						return false
+					}
+
+					name = name[i+1:]
+				}
+			}
+
+			// Otherwise ** is the same as *
+			pattern = strings.TrimLeft(pattern, "*")
+
+			// Try every length that does not cross a slash
+			for i := 0; i <= len(name); i++ {
+				if wildmatchFrom(pattern, name[i:], false) {
+					return true
+				}
+
+				if i < len(name) && name[i] == '/' {
+					return false
+				}
+			}
+
+			return false
+
+		case '?':
+			if name == "" || name[0] == '/' {
+				return false
+			}
+
+			pattern, name = pattern[1:], name[1:]
+
+		case '[':
+			matched, width, ok := matchClass(pattern, name)
+
+			// An unclosed bracket is a literal
+			if !ok {
+				if name == "" || name[0] != '[' {
+					return false
+				}
+
+				pattern, name = pattern[1:], name[1:]
+
+				continue
+			}
+
+			if !matched {
+				return false
+			}
+
+			pattern, name = pattern[width:], name[1:]
+
+		case '\\':
+			// A trailing backslash matches nothing
+			if len(pattern) == 1 || name == "" || name[0] != pattern[1] {
+				return false
+			}
+
+			pattern, name = pattern[2:], name[1:]
+
+		default:
+			if name == "" || name[0] != c {
+				return false
+			}
+
+			pattern, name = pattern[1:], name[1:]
+
+			// The next character starts a new segment
+			if c == '/' {
+				return wildmatchFrom(pattern, name, true)
+			}
+		}
+	}
+
+	return name == ""
+}
+
+// Match a character class like [a-z] or [!0-9] against the
+// first character of the name. It returns if it matched, the
+// length of the class in the pattern and false if the
+// class is not closed
+func matchClass(pattern, name string) (matched bool, width int, ok bool) {
+	i := 1
+	negate := false
+
+	if i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^') {
+		negate = true
+		i++
+	}
+
+	var c byte
+
+	if name != "" {
+		c = name[0]
+	}
+
+	for first := true; i < len(pattern); first = false {
+		// A ] right after the opening is a literal
+		if pattern[i] == ']' && !first {
+			if name == "" || c == '/' {
+				return false, i + 1, true
+			}
+
+			return matched != negate, i + 1, true
+		}
+
+		lo := pattern[i]
+
+		if lo == '\\' && i+1 < len(pattern) {
+			i++
+			lo = pattern[i]
+		}
+
+		hi := lo
+		i++
+
+		// A range like a-z
+		if i+1 < len(pattern) && pattern[i] == '-' && pattern[i+1] != ']' {
+			hi = pattern[i+1]
+
+			if hi == '\\' && i+2 < len(pattern) {
+				i++
+				hi = pattern[i+1]
+			}
+
+			i += 2
+		}
+
+		if lo <= c && c <= hi {
+			matched = true
+		}
+	}
+
+	return false, 0, false
+}
@@ -0,0 +1,124 @@
+package gitgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_wildmatch(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*.o", "main.o", true},
+		{"*.o", "main.c", false},
+		{"*.o", "dir/main.o", false}, // * does not cross /
+		{"?.txt", "a.txt", true},
+		{"?.txt", "ab.txt", false},
+		{"[abc].go", "b.go", true},
+		{"[!abc].go", "b.go", false},
+		{"[a-c]x", "cx", true},
+		{"[a-c]x", "dx", false},
+		{"[", "[", true}, // Unclosed bracket is literal
+		{`\*.o`, "*.o", true},
+		{`\*.o`, "a.o", false},
+		{`a\ `, "a ", true},
+		{"**/foo", "foo", true},
+		{"**/foo", "a/b/foo", true},
+		{"**/foo/bar", "x/foo/bar", true},
+		{"abc/**", "abc/x/y", true},
+		{"abc/**", "abc", false},
+		{"a/**/b", "a/b", true},
+		{"a/**/b", "a/x/y/b", true},
+		{"a/**/b", "a/x/y/c", false},
+		{"foo**", "foobar", true},   // Not a segment: same as *
+		{"foo**", "foo/bar", false}, // so it does not cross /
+		{"doc/*.txt", "doc/a.txt", true},
+		{"doc/*.txt", "doc/x/a.txt", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			if got := wildmatch(tt.pattern, tt.name); got != tt.want {
+				t.Errorf("wildmatch(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	f, _ := ParseIgnore(strings.NewReader(`# Build output
+*.log
+!important.log
+build/
+/root.txt
+docs/*.pdf
+logs/
+!logs/keep.txt
+`))
+
+	m := NewMatcher()
+	m.Add(".gitignore", f)
+
+	tests := []struct {
+		name, path string
+		isDir      bool
+		want       bool
+		wantLine   int
+	}{
+		{"Simple pattern", "debug.log", false, true, 2},
+		{"Pattern at any level", "a/b/debug.log", false, true, 2},
+		{"Negation wins because it is last", "important.log", false, false, 3},
+		{"Directory only matches dirs", "build", true, true, 4},
+		{"Directory only does not match files", "build", false, false, 0},
+		{"Inside an ignored directory", "build/out.o", false, true, 4},
+		{"Nested ignored directory", "src/build/out.o", false, true, 4},
+		{"Anchored at the root", "root.txt", false, true, 5},
+		{"Anchored not in a subdirectory", "sub/root.txt", false, false, 0},
+		{"Middle slash is anchored", "docs/a.pdf", false, true, 6},
+		{"Middle slash only at the root", "x/docs/a.pdf", false, false, 0},
+		{"Can not re-include inside ignored dir", "logs/keep.txt", false, true, 7},
+		{"Leading ./ is ignored", "./debug.log", false, true, 2},
+		{"Not matched", "main.go", false, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Ignored(tt.path, tt.isDir); got != tt.want {
+				t.Errorf("Ignored(%v) = %v, want %v", tt.path, got, tt.want)
+			}
+
+			match, _ := m.Match(tt.path, tt.isDir)
+
+			if match.Line != tt.wantLine {
+				t.Errorf("Match(%v) line = %v, want %v", tt.path, match.Line, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestNewTemplateMatcher(t *testing.T) {
+	m, err := NewTemplateMatcher("c")
+
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	match, ok := m.Match("build/out.o", false)
+
+	if !ok || !match.Ignored() {
+		t.Fatal("Expected build/out.o to be ignored by the C template")
+	}
+
+	if match.Source != "C" || match.Text != "*.o" {
+		t.Errorf("Got match %+v, want *.o from C", match)
+	}
+
+	if _, err := NewTemplateMatcher("C", "WakandaForever"); err == nil {
+		t.Error("Expected an error for a missing template")
+	}
+
+	if (Match{}).Ignored() {
+		t.Error("Expected a zero Match not to be ignored")
+	}
+}
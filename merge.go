@@ -0,0 +1,145 @@
+package gitgen
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// MergeIgnores adds gitignore templates to an existing .gitignore,
+// read from a reader, and writes the result to a writer. The
+// existing content is kept byte for byte, and only the rules it
+// does not have yet are added at the end, each template under a
+// "### Name ###" header. Running it again with the same templates
+// adds nothing, so it is safe to run repeatedly. It returns the
+// number of rules added. If any template does not exist nothing
+// is written and the error wraps ErrTemplateNotFound
+func MergeIgnores(existing io.Reader, keys []string, w io.Writer) (added int, err error) {
+	return defaultGenerator().MergeIgnores(existing, keys, w)
+}
+
+// MergeIgnores adds gitignore templates of the source to
+// an existing .gitignore
+func (g *Generator) MergeIgnores(existing io.Reader, keys []string, w io.Writer) (added int, err error) {
+	names, texts, err := g.loadIgnores(keys)
+
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := io.ReadAll(existing)
+
+	if err != nil {
+		return 0, err
+	}
+
+	merged, added := merge(data, names, texts)
+
+	if _, err := w.Write(merged); err != nil {
+		return 0, err
+	}
+
+	return added, nil
+}
+
+// Append the rules of the templates that are not in the data
+func merge(data []byte, names, texts []string) ([]byte, int) {
+	// The patterns already present
+	patterns := make(map[string]bool)
+
+	for _, line := range parseIgnoreBytes(data).Lines {
+		if line.Kind == LinePattern {
+			patterns[patternKey(line.Text)] = true
+		}
+	}
+
+	out := bytes.NewBuffer(data)
+	added := 0
+
+	for i, name := range names {
+		var section []string
+
+		for _, block := range ignoreBlocks(texts[i]) {
+			if kept, n := newRules(block, patterns); n != 0 {
+				section = append(section, kept)
+				added += n
+			}
+		}
+
+		// Nothing new from this template
+		if len(section) == 0 {
+			continue
+		}
+
+		// Do not glue the header to the last line
+		if out.Len() != 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+			out.WriteString("\n")
+		}
+
+		if out.Len() != 0 {
+			out.WriteString("\n")
+		}
+
+		out.WriteString("### " + name + " ###\n")
+		out.WriteString(strings.Join(section, "\n"))
+	}
+
+	return out.Bytes(), added
+}
+
+// Split a template in blocks separated by blank lines
+func ignoreBlocks(text string) [][]Line {
+	var blocks [][]Line
+	var current []Line
+
+	for _, line := range parseIgnoreBytes([]byte(text)).Lines {
+		if line.Kind == LineBlank {
+			if len(current) != 0 {
+				blocks = append(blocks, current)
+			}
+
+			current = nil
+			continue
+		}
+
+		current = append(current, line)
+	}
+
+	if len(current) != 0 {
+		blocks = append(blocks, current)
+	}
+
+	return blocks
+}
+
+// Get the comments and the missing patterns of a block, which are
+// marked as present. If there are no missing patterns the block
+// is dropped, comments included. It returns the block as a single
+// string, with the line endings of the template, and the number of
+// patterns kept
+func newRules(block []Line, patterns map[string]bool) (string, int) {
+	b := new(strings.Builder)
+
+	n := 0
+
+	for _, line := range block {
+		if line.Kind == LinePattern {
+			key := patternKey(line.Text)
+
+			if patterns[key] {
+				continue
+			}
+
+			patterns[key] = true
+			n++
+		}
+
+		b.WriteString(line.Text + lineEnding(line))
+	}
+
+	if n == 0 {
+		return "", 0
+	}
+
+	return b.String(), n
+}
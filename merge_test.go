@@ -0,0 +1,104 @@
+package gitgen
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMergeIgnores(t *testing.T) {
+	existing := "# Ours\nsecret.txt\n*.o\n"
+
+	w := new(bytes.Buffer)
+
+	added, err := MergeIgnores(strings.NewReader(existing), []string{"Ada", "CUDA"}, w)
+
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	// *.o was already there, Ada adds *.ali and CUDA adds 6 rules
+	if added != 7 {
+		t.Errorf("Expected 7 rules added, got %d", added)
+	}
+
+	want := existing + `
+### Ada ###
+# Ada Library Information
+*.ali
+
+### CUDA ###
+*.i
+*.ii
+*.gpu
+*.ptx
+*.cubin
+*.fatbin
+`
+
+	if got := w.String(); got != want {
+		t.Errorf("MergeIgnores() = %q, want %q", got, want)
+	}
+
+	t.Run("Idempotent", func(t *testing.T) {
+		again := new(bytes.Buffer)
+
+		added, _ := MergeIgnores(strings.NewReader(want), []string{"Ada", "cuda"}, again)
+
+		if added != 0 || again.String() != want {
+			t.Errorf("Second merge added %d rules and changed the file", added)
+		}
+	})
+}
+
+func TestMergeIgnores_noFinalNewline(t *testing.T) {
+	w := new(bytes.Buffer)
+
+	MergeIgnores(strings.NewReader("secret.txt"), []string{"Ada"}, w)
+
+	if got := w.String(); !strings.HasPrefix(got, "secret.txt\n\n### Ada ###\n") {
+		t.Errorf("MergeIgnores() = %q", got)
+	}
+}
+
+func TestMergeIgnores_lineEndings(t *testing.T) {
+	w := new(bytes.Buffer)
+
+	MergeIgnores(strings.NewReader(""), []string{"macOS"}, w)
+
+	// Icon must end with two carriage returns
+	if got := w.String(); !strings.Contains(got, "\nIcon\r\r\n") {
+		t.Errorf("MergeIgnores() = %q", got)
+	}
+}
+
+func Test_merge_lineEndings(t *testing.T) {
+	got, added := merge([]byte("*.o\n"), []string{"macOS"}, []string{"# Icon\nIcon\r\r\n*.o\r\n\n*.tmp"})
+
+	want := "*.o\n\n### macOS ###\n# Icon\nIcon\r\r\n\n*.tmp\n"
+
+	if added != 2 || string(got) != want {
+		t.Errorf("merge() = %q, %d, want %q, 2", got, added, want)
+	}
+}
+
+func TestMergeIgnores_empty(t *testing.T) {
+	w := new(bytes.Buffer)
+
+	MergeIgnores(strings.NewReader(""), []string{"Ada"}, w)
+
+	if got := w.String(); !strings.HasPrefix(got, "### Ada ###\n# Object file\n*.o\n") {
+		t.Errorf("MergeIgnores() = %q", got)
+	}
+}
+
+func TestMergeIgnores_badKey(t *testing.T) {
+	w := new(bytes.Buffer)
+
+	_, err := MergeIgnores(strings.NewReader("a\n"), []string{"WakandaForever"}, w)
+
+	if !errors.Is(err, ErrTemplateNotFound) || w.Len() != 0 {
+		t.Errorf("Expected ErrTemplateNotFound and no output, got %v", err)
+	}
+}
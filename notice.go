@@ -0,0 +1,128 @@
+package gitgen
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+)
+
+// The notice of the licenses without one in notices/key.txt,
+// with the SPDX identifier of the license after it
+const defaultNotice = "Copyright (c) [year] [fullname]\n\nSPDX-License-Identifier: "
+
+// HasNotice tells if a license comes with its own copyright notice,
+// like the GNU licenses. Those licenses are written verbatim by
+// RenderLicense, as the placeholders of their text are in the
+// instructions at the end of it, and the copyright goes in the
+// notice instead: in a COPYRIGHT file or at the top of the sources
+func HasNotice(key string) bool {
+	return defaultGenerator().HasNotice(key)
+}
+
+// HasNotice tells if a license of the source comes
+// with its own copyright notice
+func (g *Generator) HasNotice(key string) bool {
+	_, err := g.source().Stat(g.noticePath(key))
+
+	return err == nil
+}
+
+// Notice returns the short copyright notice of a license with the
+// placeholders filled with the parameters. It is the one in
+// notices/key.txt for licenses like the GPL, or the copyright and
+// the SPDX identifier of the license for the rest. If the license
+// does not exist the error wraps ErrTemplateNotFound
+func Notice(key string, params LicenseParams) (string, error) {
+	return defaultGenerator().Notice(key, params)
+}
+
+// Notice returns the short copyright notice of a license of the source
+func (g *Generator) Notice(key string, params LicenseParams) (string, error) {
+	txt, err := g.noticeText(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	return params.replacer(txt).Replace(txt), nil
+}
+
+// RenderNotice writes the short copyright notice of a
+// license to a writer, like Notice
+func RenderNotice(key string, params LicenseParams, w io.Writer) (int, error) {
+	return defaultGenerator().RenderNotice(key, params, w)
+}
+
+// RenderNotice writes the short copyright notice
+// of a license of the source to a writer
+func (g *Generator) RenderNotice(key string, params LicenseParams, w io.Writer) (int, error) {
+	txt, err := g.Notice(key, params)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return io.WriteString(w, txt)
+}
+
+// RenderNoticeStrict is like RenderNotice, but fails with a
+// *PlaceholderError if a placeholder is left unfilled, like
+// RenderLicenseStrict. Nothing is written if there is an error
+func RenderNoticeStrict(key string, params LicenseParams, w io.Writer) (int, error) {
+	return defaultGenerator().RenderNoticeStrict(key, params, w)
+}
+
+// RenderNoticeStrict writes the short copyright notice of a license
+// of the source with all the placeholders filled to a writer
+func (g *Generator) RenderNoticeStrict(key string, params LicenseParams, w io.Writer) (int, error) {
+	txt, err := g.Notice(key, params)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if left := placeholdersIn(txt); len(left) != 0 {
+		return 0, &PlaceholderError{g.resolveLicense(key), left}
+	}
+
+	return io.WriteString(w, txt)
+}
+
+// Get the notice of a license without filling it
+func (g *Generator) noticeText(key string) (string, error) {
+	// The license must exist even if its notice is the default one
+	if _, err := g.licenseAsset(key); err != nil {
+		return "", err
+	}
+
+	data, err := g.asset(g.noticePath(key))
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return defaultNotice + headerSPDX(g.resolveLicense(key)) + "\n", nil
+	}
+
+	return string(data), err
+}
+
+// The path of the notice of a license
+func (g *Generator) noticePath(key string) string {
+	return "notices/" + g.resolveLicense(key) + ".txt"
+}
+
+// Get the SPDX identifier of the headers of a license. The
+// licenses without metadata use their key
+func headerSPDX(key string) string {
+	for _, lic := range licenseInfo {
+		if lic.Key != key {
+			continue
+		}
+
+		if lic.HeaderSPDX != "" {
+			return lic.HeaderSPDX
+		}
+
+		return lic.SPDX
+	}
+
+	return key
+}
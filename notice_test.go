@@ -0,0 +1,146 @@
+package gitgen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHasNotice(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"gpl-2.0", true},
+		{"gpl-3.0", true},
+		{"agpl-3.0", true},
+		{"lgpl-2.1", true},
+		{"GPL", true},
+		{"mit", false},
+		{"apache-2.0", false},
+		{"not-a-license", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := HasNotice(tt.key); got != tt.want {
+				t.Errorf("HasNotice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotice(t *testing.T) {
+	params := LicenseParams{
+		Holders:     []string{"eacp"},
+		Year:        "2021",
+		Project:     "gitgen",
+		Description: "Generate files for git",
+	}
+
+	tests := []struct {
+		name, key string
+		wantStart string
+	}{
+		{
+			"GPL",
+			"gpl-3.0",
+			"gitgen: Generate files for git\nCopyright (C) 2021  eacp\n\nThis program is free software",
+		},
+
+		{
+			"LGPL",
+			"lgpl-2.1",
+			"gitgen: Generate files for git\nCopyright (C) 2021  eacp\n\nThis library is free software",
+		},
+
+		{
+			"Default notice",
+			"mit",
+			"Copyright (c) 2021 eacp\n\nSPDX-License-Identifier: MIT\n",
+		},
+
+		{
+			"Alias",
+			"apache",
+			"Copyright (c) 2021 eacp\n\nSPDX-License-Identifier: Apache-2.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Notice(tt.key, params)
+
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			if !strings.HasPrefix(got, tt.wantStart) {
+				t.Errorf("Notice() = %q, want it to start with %q", got, tt.wantStart)
+			}
+
+			if left := placeholdersIn(got); len(left) != 0 {
+				t.Errorf("Placeholders left: %v", left)
+			}
+		})
+	}
+
+	if _, err := Notice("not-a-license", params); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+	}
+}
+
+func TestRenderNoticeStrict(t *testing.T) {
+	b := new(strings.Builder)
+
+	_, err := RenderNoticeStrict("gpl-3.0", LicenseParams{Holders: []string{"eacp"}}, b)
+
+	var pe *PlaceholderError
+
+	if !errors.As(err, &pe) {
+		t.Fatalf("Expected a PlaceholderError, got %v", err)
+	}
+
+	want := "<one line to give the program's name and a brief idea of what it does.> <year>"
+
+	if got := strings.Join(pe.Placeholders, " "); got != want {
+		t.Errorf("Placeholders = %v, want %v", got, want)
+	}
+
+	if b.Len() != 0 {
+		t.Errorf("Wrote %d bytes with an error", b.Len())
+	}
+
+	n, err := RenderNoticeStrict("mit", LicenseParams{Holders: []string{"eacp"}, Year: "2021"}, b)
+
+	if err != nil || n != b.Len() || b.String() != "Copyright (c) 2021 eacp\n\nSPDX-License-Identifier: MIT\n" {
+		t.Errorf("RenderNoticeStrict() = %q, %v", b.String(), err)
+	}
+}
+
+func TestGenerator_Notice(t *testing.T) {
+	g := NewGenerator(&FSSource{"test", fstest.MapFS{
+		"licenses/acme.txt":   {Data: []byte("Acme license <year>")},
+		"licenses/shared.txt": {Data: []byte("Shared license <year>")},
+		"notices/shared.txt":  {Data: []byte("Shared, <year> <name of author>\n")},
+	}})
+
+	params := LicenseParams{Holders: []string{"eacp"}, Year: "2021"}
+
+	// Without metadata the key is the identifier
+	if got, _ := g.Notice("acme", params); got != "Copyright (c) 2021 eacp\n\nSPDX-License-Identifier: acme\n" {
+		t.Errorf("Notice() = %q", got)
+	}
+
+	if got, _ := g.Notice("shared", params); got != "Shared, 2021 eacp\n" {
+		t.Errorf("Notice() = %q", got)
+	}
+
+	// The license with a notice is written verbatim
+	b := new(strings.Builder)
+
+	if _, err := g.RenderLicense("shared", params, b); err != nil || b.String() != "Shared license <year>" {
+		t.Errorf("RenderLicense() = %q, %v", b.String(), err)
+	}
+}
@@ -0,0 +1,119 @@
+package gitgen
+
+import "strings"
+
+// Requirement is the answer to a question about a license
+type Requirement int
+
+const (
+	// Any means the feature does not matter
+	Any Requirement = iota
+
+	// Required means the license must have the feature
+	Required
+
+	// Forbidden means the license must not have the feature
+	Forbidden
+)
+
+// Criteria describes what a project needs from its license
+type Criteria struct {
+	// Copyleft requires the source of changes to be disclosed
+	Copyleft Requirement
+
+	// PatentGrant requires an express grant of patent rights
+	PatentGrant Requirement
+
+	// NetworkDisclosure requires the source to be disclosed when
+	// the software is used over a network, like the AGPL does
+	NetworkDisclosure Requirement
+
+	// SameLicense requires changes to use the same license
+	SameLicense Requirement
+}
+
+// Recommendation is a license that fits some criteria
+type Recommendation struct {
+	License License
+
+	// Reasons explains what the license does for each
+	// criteria that is not Any
+	Reasons []string
+}
+
+// A feature of a license asked by the criteria
+type licenseTrait struct {
+	req     Requirement
+	has     bool
+	yes, no string
+}
+
+// RecommendLicenses returns the licenses that fit the criteria,
+// sorted by key, with a short explanation of why they fit
+func RecommendLicenses(c Criteria) []Recommendation {
+	return defaultGenerator().RecommendLicenses(c)
+}
+
+// RecommendLicenses returns the licenses of the
+// source that fit the criteria
+func (g *Generator) RecommendLicenses(c Criteria) []Recommendation {
+	var recs []Recommendation
+
+	for _, lic := range g.Licenses() {
+		traits := []licenseTrait{
+			{c.Copyleft, hasPrefix(lic.Conditions, "disclose-source"),
+				"copyleft: the source of changes must be disclosed",
+				"permissive: changes can be kept closed"},
+			{c.PatentGrant, hasPrefix(lic.Permissions, "patent-use"),
+				"grants patent rights",
+				"does not grant patent rights"},
+			{c.NetworkDisclosure, hasPrefix(lic.Conditions, "network-use-disclose"),
+				"the source must be shared with users over a network",
+				"network use does not count as distribution"},
+			{c.SameLicense, hasPrefix(lic.Conditions, "same-license"),
+				"changes must use the same license",
+				"changes can use other licenses"},
+		}
+
+		if reasons, ok := checkTraits(traits); ok {
+			recs = append(recs, Recommendation{lic, reasons})
+		}
+	}
+
+	return recs
+}
+
+// Check every trait, returning the reasons if all of them match
+func checkTraits(traits []licenseTrait) ([]string, bool) {
+	var reasons []string
+
+	for _, t := range traits {
+		switch {
+		case t.req == Any:
+			continue
+
+		case t.req == Required && !t.has, t.req == Forbidden && t.has:
+			return nil, false
+
+		case t.has:
+			reasons = append(reasons, t.yes)
+
+		default:
+			reasons = append(reasons, t.no)
+		}
+	}
+
+	return reasons, true
+}
+
+// Check if any item starts with a prefix, so same-license
+// also matches same-license--file and same-license--library
+func hasPrefix(items []string, prefix string) bool {
+	for _, item := range items {
+		if strings.HasPrefix(item, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
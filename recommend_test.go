@@ -0,0 +1,71 @@
+package gitgen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecommendLicenses(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria Criteria
+		want     []string
+	}{
+		{
+			"Permissive with patent grant",
+			Criteria{Copyleft: Forbidden, PatentGrant: Required},
+			[]string{"apache-2.0"},
+		},
+		{
+			"Network disclosure",
+			Criteria{NetworkDisclosure: Required},
+			[]string{"agpl-3.0"},
+		},
+		{
+			"Copyleft with patents, no network",
+			Criteria{Copyleft: Required, PatentGrant: Required, NetworkDisclosure: Forbidden},
+			[]string{"epl-2.0", "gpl-3.0", "mpl-2.0"},
+		},
+		{
+			"Permissive without patents",
+			Criteria{Copyleft: Forbidden, PatentGrant: Forbidden},
+			[]string{"bsd-2-clause", "bsd-3-clause", "bsl-1.0", "cc0-1.0", "mit", "unlicense"},
+		},
+		{
+			"Impossible",
+			Criteria{Copyleft: Forbidden, SameLicense: Required},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+
+			for _, rec := range RecommendLicenses(tt.criteria) {
+				got = append(got, rec.License.Key)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RecommendLicenses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecommendLicenses_reasons(t *testing.T) {
+	recs := RecommendLicenses(Criteria{Copyleft: Forbidden, PatentGrant: Required})
+
+	want := []string{"permissive: changes can be kept closed", "grants patent rights"}
+
+	if len(recs) != 1 || !reflect.DeepEqual(recs[0].Reasons, want) {
+		t.Errorf("Expected the reasons %v, got %+v", want, recs)
+	}
+}
+
+func TestRecommendLicenses_any(t *testing.T) {
+	// Without criteria every license fits
+	if got, want := len(RecommendLicenses(Criteria{})), len(Licenses()); got != want {
+		t.Errorf("Expected %d licenses, got %d", want, got)
+	}
+}
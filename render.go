@@ -0,0 +1,222 @@
+package gitgen
+
+import (
+	"io"
+	"sort"
+	"strings"
+)
+
+// LicenseParams are the values filled in the placeholders of a
+// license. Empty values leave their placeholders as they are
+type LicenseParams struct {
+	// Holders are the copyright holders, joined with commas
+	Holders []string
+
+	// Year is the year of the copyright, like 2021,
+	// or a range, like 2019-2021
+	Year string
+
+	// Email is added after the holders, like Name <email>
+	Email string
+
+	// Project is the name of the program or library
+	Project string
+
+	// Description is a brief idea of what the project does
+	Description string
+
+	// URL is the home page of the project
+	URL string
+
+	// Organization owns the copyright. It is the holder
+	// if there are no Holders
+	Organization string
+}
+
+// The placeholders of each parameter. The first ones are used by
+// the embeded licenses, the ones in brackets can be used in the
+// licenses of the user
+var (
+	yearPlaceholders = []string{"[year]", "[yyyy]", "<year>"}
+
+	holderPlaceholders = []string{
+		"[fullname]",
+		"[name of copyright owner]",
+		"<name of author>",
+		"<signature of Ty Coon>",
+	}
+
+	projectPlaceholders = []string{"<program>", "[project]"}
+
+	// The GNU licenses want the name and the description in one line
+	summaryPlaceholders = []string{
+		"<one line to give the program's name and a brief idea of what it does.>",
+		"<one line to give the library's name and a brief idea of what it does.>",
+	}
+
+	emailPlaceholders        = []string{"[email]"}
+	descriptionPlaceholders  = []string{"[description]"}
+	urlPlaceholders          = []string{"[url]"}
+	organizationPlaceholders = []string{"[organization]"}
+)
+
+// RenderLicense writes a license to a writer with the placeholders
+// filled with the parameters. Every license has its own
+// placeholders, like [year] in the MIT license and <year> in the
+// GPL, and all of them are filled. Licenses with their own notice,
+// see HasNotice, are written verbatim. If the license does not
+// exist the error wraps ErrTemplateNotFound
+func RenderLicense(key string, params LicenseParams, w io.Writer) (int, error) {
+	return defaultGenerator().RenderLicense(key, params, w)
+}
+
+// RenderLicense writes a license of the source to a writer
+// with the placeholders filled with the parameters
+func (g *Generator) RenderLicense(key string, params LicenseParams, w io.Writer) (int, error) {
+	txt, err := g.renderLicense(key, params)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return io.WriteString(w, txt)
+}
+
+// RenderLicenseStrict is like RenderLicense, but fails if a
+// placeholder is left unfilled instead of writing a half filled
+// license. The error is a *PlaceholderError that lists all of
+// them and wraps ErrUnfilledPlaceholders. Nothing is written
+// if there is an error
+func RenderLicenseStrict(key string, params LicenseParams, w io.Writer) (int, error) {
+	return defaultGenerator().RenderLicenseStrict(key, params, w)
+}
+
+// RenderLicenseStrict writes a license of the source to a writer
+// with all the placeholders filled with the parameters
+func (g *Generator) RenderLicenseStrict(key string, params LicenseParams, w io.Writer) (int, error) {
+	txt, err := g.renderLicense(key, params)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// The placeholders of the licenses written verbatim
+	// are in their instructions, they are not meant to be filled
+	if left := placeholdersIn(txt); len(left) != 0 && !g.HasNotice(key) {
+		return 0, &PlaceholderError{g.resolveLicense(key), left}
+	}
+
+	return io.WriteString(w, txt)
+}
+
+// Placeholders returns the placeholders of a license that
+// RenderLicense can fill, like [year] or <name of author>, in the
+// order they appear. It is nil if the license does not exist, and
+// for the licenses written verbatim, as theirs are in the notice
+func Placeholders(key string) []string {
+	return defaultGenerator().Placeholders(key)
+}
+
+// Placeholders returns the placeholders of a license of the source
+func (g *Generator) Placeholders(key string) []string {
+	if g.HasNotice(key) {
+		return nil
+	}
+
+	txt, err := g.LicenseText(key)
+
+	if err != nil {
+		return nil
+	}
+
+	return placeholdersIn(txt)
+}
+
+// Get the text of a license with the placeholders filled,
+// unless the license is written verbatim
+func (g *Generator) renderLicense(key string, params LicenseParams) (string, error) {
+	txt, err := g.LicenseText(key)
+
+	if err != nil || g.HasNotice(key) {
+		return txt, err
+	}
+
+	return params.replacer(txt).Replace(txt), nil
+}
+
+// Find the known placeholders of a text, in the order they appear
+func placeholdersIn(text string) []string {
+	var found []string
+
+	pos := make(map[string]int)
+
+	for _, list := range [][]string{
+		yearPlaceholders, holderPlaceholders, projectPlaceholders,
+		summaryPlaceholders, emailPlaceholders, descriptionPlaceholders,
+		urlPlaceholders, organizationPlaceholders,
+	} {
+		for _, placeholder := range list {
+			if i := strings.Index(text, placeholder); i >= 0 {
+				pos[placeholder] = i
+				found = append(found, placeholder)
+			}
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return pos[found[i]] < pos[found[j]]
+	})
+
+	return found
+}
+
+// The holders of the copyright, with the email
+// unless the text has a place for it
+func (p LicenseParams) holder(text string) string {
+	holder := strings.Join(p.Holders, ", ")
+
+	if holder == "" {
+		holder = p.Organization
+	}
+
+	if holder != "" && p.Email != "" && !strings.Contains(text, "[email]") {
+		holder += " <" + p.Email + ">"
+	}
+
+	return holder
+}
+
+// The name and the description of the project in one line
+func (p LicenseParams) summary() string {
+	if p.Project != "" && p.Description != "" {
+		return p.Project + ": " + p.Description
+	}
+
+	return p.Project
+}
+
+// Make a replacer for the placeholders of a text that have a value
+func (p LicenseParams) replacer(text string) *strings.Replacer {
+	var pairs []string
+
+	add := func(placeholders []string, val string) {
+		if val == "" {
+			return
+		}
+
+		for _, placeholder := range placeholders {
+			pairs = append(pairs, placeholder, val)
+		}
+	}
+
+	add(yearPlaceholders, p.Year)
+	add(holderPlaceholders, p.holder(text))
+	add(projectPlaceholders, p.Project)
+	add(summaryPlaceholders, p.summary())
+	add(emailPlaceholders, p.Email)
+	add(descriptionPlaceholders, p.Description)
+	add(urlPlaceholders, p.URL)
+	add(organizationPlaceholders, p.Organization)
+
+	return strings.NewReplacer(pairs...)
+}
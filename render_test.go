@@ -0,0 +1,229 @@
+package gitgen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRenderLicense(t *testing.T) {
+	g := NewGenerator(&FSSource{"test", fstest.MapFS{
+		"licenses/plain.txt":  {Data: []byte("Copyright [year] [fullname]")},
+		"licenses/gnu.txt":    {Data: []byte("<one line to give the program's name and a brief idea of what it does.>\n<program>  Copyright (C) <year>  <name of author>")},
+		"licenses/custom.txt": {Data: []byte("[project] ([url]): [description]. [organization], [email]")},
+	}})
+
+	tests := []struct {
+		name, key string
+		params    LicenseParams
+		want      string
+	}{
+		{
+			"Name and year",
+			"plain", LicenseParams{Holders: []string{"eacp"}, Year: "2021"},
+			"Copyright 2021 eacp",
+		},
+
+		{
+			"Several holders, a range and an email",
+			"plain", LicenseParams{Holders: []string{"Ana", "Bo"}, Year: "2019-2021", Email: "ana@example.com"},
+			"Copyright 2019-2021 Ana, Bo <ana@example.com>",
+		},
+
+		{
+			"The organization is the holder",
+			"plain", LicenseParams{Organization: "Acme", Year: "2021"},
+			"Copyright 2021 Acme",
+		},
+
+		{
+			"Empty values are not filled",
+			"plain", LicenseParams{Year: "2021"},
+			"Copyright 2021 [fullname]",
+		},
+
+		{
+			"GNU placeholders",
+			"gnu", LicenseParams{Holders: []string{"eacp"}, Year: "2021", Project: "gitgen", Description: "Generate files for git"},
+			"gitgen: Generate files for git\ngitgen  Copyright (C) 2021  eacp",
+		},
+
+		{
+			"Project without a description",
+			"gnu", LicenseParams{Holders: []string{"eacp"}, Year: "2021", Project: "gitgen"},
+			"gitgen\ngitgen  Copyright (C) 2021  eacp",
+		},
+
+		{
+			"Custom placeholders",
+			"custom", LicenseParams{
+				Project: "gitgen", URL: "https://example.com", Description: "Generate files",
+				Organization: "Acme", Email: "dev@example.com",
+			},
+			"gitgen (https://example.com): Generate files. Acme, dev@example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(strings.Builder)
+
+			n, err := g.RenderLicense(tt.key, tt.params, b)
+
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			if got := b.String(); got != tt.want || n != len(tt.want) {
+				t.Errorf("RenderLicense() = %q (%d bytes), want %q", got, n, tt.want)
+			}
+		})
+	}
+
+	if _, err := g.RenderLicense("mit", LicenseParams{}, new(strings.Builder)); err == nil {
+		t.Error("Expected an error for a missing license")
+	}
+}
+
+func TestRenderLicense_Embedded(t *testing.T) {
+	params := LicenseParams{
+		Holders:     []string{"Eduardo Castillo"},
+		Year:        "2021",
+		Project:     "gitgen",
+		Description: "Generate files for git",
+	}
+
+	// Every placeholder of the metadata is filled,
+	// unless the license is written verbatim
+	for _, lic := range Licenses() {
+		t.Run(lic.Key, func(t *testing.T) {
+			b := new(strings.Builder)
+
+			if _, err := RenderLicense(lic.Key, params, b); err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			if HasNotice(lic.Key) {
+				if b.String() != GetLicenseText(lic.Key) {
+					t.Error("The license was not written verbatim")
+				}
+
+				return
+			}
+
+			for _, placeholder := range lic.Placeholders {
+				if strings.Contains(b.String(), placeholder) {
+					t.Errorf("%v was not filled", placeholder)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	// The metadata lists the same placeholders, in any order.
+	// The ones of the licenses written verbatim are not filled
+	for _, lic := range Licenses() {
+		t.Run(lic.Key, func(t *testing.T) {
+			got := Placeholders(lic.Key)
+
+			if HasNotice(lic.Key) {
+				if got != nil {
+					t.Errorf("Placeholders() = %v for a license with a notice", got)
+				}
+
+				return
+			}
+
+			if len(got) != len(lic.Placeholders) {
+				t.Fatalf("Placeholders() = %v, want %v", got, lic.Placeholders)
+			}
+
+			for _, placeholder := range lic.Placeholders {
+				if !hasString(got, placeholder) {
+					t.Errorf("%v is missing in %v", placeholder, got)
+				}
+			}
+		})
+	}
+
+	// In the order they appear
+	want := []string{"[year]", "[fullname]"}
+
+	if got := Placeholders("MIT"); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Placeholders() = %v, want %v", got, want)
+	}
+
+	if got := Placeholders("not-a-license"); got != nil {
+		t.Errorf("Placeholders() = %v for a missing license", got)
+	}
+}
+
+func TestRenderLicenseStrict(t *testing.T) {
+	tests := []struct {
+		name, key string
+		params    LicenseParams
+		wantLeft  []string
+	}{
+		{"Everything filled", "mit", LicenseParams{Holders: []string{"eacp"}, Year: "2021"}, nil},
+		{"No placeholders", "mpl-2.0", LicenseParams{}, nil},
+		{"Nothing filled", "mit", LicenseParams{}, []string{"[year]", "[fullname]"}},
+		{"Without a year", "apache-2.0", LicenseParams{Holders: []string{"eacp"}}, []string{"[yyyy]"}},
+
+		{"Verbatim", "gpl-3.0", LicenseParams{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(strings.Builder)
+
+			n, err := RenderLicenseStrict(tt.key, tt.params, b)
+
+			if tt.wantLeft == nil {
+				if err != nil {
+					t.Fatal("Unexpected error: ", err)
+				}
+
+				if n == 0 || n != b.Len() {
+					t.Errorf("Wrote %d bytes, the writer has %d", n, b.Len())
+				}
+
+				return
+			}
+
+			var pe *PlaceholderError
+
+			if !errors.As(err, &pe) || !errors.Is(err, ErrUnfilledPlaceholders) {
+				t.Fatalf("Expected a PlaceholderError, got %v", err)
+			}
+
+			if strings.Join(pe.Placeholders, " ") != strings.Join(tt.wantLeft, " ") {
+				t.Errorf("Placeholders = %v, want %v", pe.Placeholders, tt.wantLeft)
+			}
+
+			if pe.Key != tt.key {
+				t.Errorf("Key = %v, want %v", pe.Key, tt.key)
+			}
+
+			// Nothing half filled is written
+			if n != 0 || b.Len() != 0 {
+				t.Errorf("Wrote %d bytes with an error", b.Len())
+			}
+		})
+	}
+
+	if _, err := RenderLicenseStrict("not-a-license", LicenseParams{}, new(strings.Builder)); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+	}
+}
+
+func hasString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,172 @@
+package gitgen
+
+import (
+	"bufio"
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// Alias tables, embeded in assets/aliases. The keys are in lower case
+var (
+	ignoreAliases  = loadAliases("aliases/ignores.txt")
+	licenseAliases = loadAliases("aliases/licenses.txt")
+)
+
+// Parse an alias file. Each line has an alias and its
+// target separated by spaces. Lines starting with # are comments
+func loadAliases(name string) map[string]string {
+	aliases := make(map[string]string)
+
+	data, err := asset(name)
+
+	if err != nil {
+		return aliases
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		// Skip comments, blank lines and malformed lines
+		if len(fields) != 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		aliases[strings.ToLower(fields[0])] = fields[1]
+	}
+
+	return aliases
+}
+
+// Get the name of the gitignore template for a key
+func resolveIgnore(key string) string {
+	return defaultGenerator().resolveIgnore(key)
+}
+
+// Get the SPDX identifier of the license for a key
+func resolveLicense(key string) string {
+	return defaultGenerator().resolveLicense(key)
+}
+
+// The names of the gitignore templates, without extension
+func ignoreNames() []string {
+	return defaultGenerator().ignoreNames()
+}
+
+// The SPDX identifiers of the licenses
+func licenseNames() []string {
+	return defaultGenerator().licenseNames()
+}
+
+func (g *Generator) resolveIgnore(key string) string {
+	return resolve(key, g.ignoreNames(), ignoreAliases)
+}
+
+func (g *Generator) resolveLicense(key string) string {
+	return resolve(key, g.licenseNames(), licenseAliases)
+}
+
+// The names of the project templates and then the
+// global ones. A project template hides a global
+// one with the same name
+func (g *Generator) ignoreNames() []string {
+	names := g.templateNames(ignoresFolder, ".gitignore")
+
+	seen := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		seen[name] = true
+	}
+
+	for _, name := range g.templateNames(globalFolder, ".gitignore") {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Get the file of a gitignore template. Project templates go
+// first. If there is no such template the path of a
+// missing project template is returned
+func (g *Generator) ignorePath(name string) string {
+	project := ignoresFolder + "/" + name + ".gitignore"
+
+	if _, err := g.source().Stat(project); err == nil {
+		return project
+	}
+
+	global := globalFolder + "/" + name + ".gitignore"
+
+	if _, err := g.source().Stat(global); err == nil {
+		return global
+	}
+
+	return project
+}
+
+func (g *Generator) licenseNames() []string {
+	return g.templateNames("licenses", ".txt")
+}
+
+// Find the template name for a key. An exact match wins, then
+// a case insensitive match and finally an alias. If nothing
+// matches the key is returned unchanged
+func resolve(key string, names []string, aliases map[string]string) string {
+	for _, name := range names {
+		if name == key {
+			return name
+		}
+	}
+
+	for _, name := range names {
+		if strings.EqualFold(name, key) {
+			return name
+		}
+	}
+
+	if target, ok := aliases[strings.ToLower(key)]; ok {
+		return target
+	}
+
+	return key
+}
+
+// Return the names of the templates in a folder, without
+// the file extension. Other files are skipped
+func (g *Generator) templateNames(folder, ext string) []string {
+	var names []string
+
+	for _, file := range g.listAssets(folder) {
+		if strings.HasSuffix(file, ext) && file != ext {
+			names = append(names, strings.TrimSuffix(file, ext))
+		}
+	}
+
+	return names
+}
+
+// Return the templates of a folder, sorted, with their source
+func (g *Generator) templates(folder, ext string) []Template {
+	names := sortNames(g.templateNames(folder, ext))
+
+	list := make([]Template, len(names))
+
+	for i, name := range names {
+		list[i] = Template{Name: name, Source: sourceName(g.source(), folder+"/"+name+ext)}
+	}
+
+	return list
+}
+
+// Sort names ignoring the case
+func sortNames(names []string) []string {
+	sort.Slice(names, func(i, j int) bool {
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+
+	return names
+}
@@ -0,0 +1,71 @@
+package gitgen
+
+import "testing"
+
+func Test_resolveIgnore(t *testing.T) {
+	tests := []struct {
+		name, key, want string
+	}{
+		{"Exact name", "Node", "Node"},
+		{"Lower case", "node", "Node"},
+		{"Upper case", "GCOV", "gcov"},
+		{"Alias cpp", "cpp", "C++"},
+		{"Alias golang", "golang", "Go"},
+		{"Alias js", "js", "Node"},
+		{"Alias nodejs with odd casing", "NodeJS", "Node"},
+		{"Unknown key is unchanged", "WakandaForever", "WakandaForever"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveIgnore(tt.key); got != tt.want {
+				t.Errorf("resolveIgnore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_resolveLicense(t *testing.T) {
+	tests := []struct {
+		name, key, want string
+	}{
+		{"Exact name", "mit", "mit"},
+		{"Upper case", "MIT", "mit"},
+		{"Mixed case SPDX", "Apache-2.0", "apache-2.0"},
+		{"Alias Apache2", "Apache2", "apache-2.0"},
+		{"Alias gplv3", "GPLv3", "gpl-3.0"},
+		{"Unknown key is unchanged", "lol", "lol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveLicense(tt.key); got != tt.want {
+				t.Errorf("resolveLicense() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Every alias must point to an existing template
+func Test_aliasTargets(t *testing.T) {
+	tables := []struct {
+		name    string
+		aliases map[string]string
+		lookup  func(string) (string, error)
+	}{
+		{"ignores", ignoreAliases, IgnoreText},
+		{"licenses", licenseAliases, LicenseText},
+	}
+
+	for _, table := range tables {
+		if len(table.aliases) == 0 {
+			t.Errorf("No aliases loaded for %v", table.name)
+		}
+
+		for alias, target := range table.aliases {
+			if _, err := table.lookup(target); err != nil {
+				t.Errorf("Alias '%v' points to '%v': %v", alias, target, err)
+			}
+		}
+	}
+}
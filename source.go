@@ -0,0 +1,191 @@
+package gitgen
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// Source is a set of templates. The names are paths with forward
+// slashes, like ignores/Go.gitignore or licenses/mit.txt, the same
+// layout as the embeded assets. Errors for missing files wrap
+// fs.ErrNotExist
+type Source interface {
+	// Get returns the content of a file
+	Get(name string) ([]byte, error)
+
+	// List returns the names of the files in a folder,
+	// without the folder. Subfolders are not included
+	List(folder string) ([]string, error)
+
+	// Stat returns the information of a file
+	Stat(name string) (fs.FileInfo, error)
+}
+
+// FSSource is a Source that reads the templates from an fs.FS
+type FSSource struct {
+	// Name is shown as the source of the templates
+	Name string
+
+	FS fs.FS
+}
+
+// The templates that come with the package
+var embedded = newEmbedded()
+
+func newEmbedded() *FSSource {
+	sub, err := fs.Sub(assets, "assets")
+
+	// Only happens if the folder is renamed
+	if err != nil {
+		panic(err)
+	}
+
+	return &FSSource{EmbeddedSource, sub}
+}
+
+// Embedded returns the Source of the templates
+// embeded in this version of the package
+func Embedded() Source {
+	return embedded
+}
+
+// DirSource returns a Source that reads the templates from a
+// directory on disk. The directory is used as its name
+func DirSource(dir string) *FSSource {
+	return &FSSource{dir, os.DirFS(dir)}
+}
+
+// Get returns the content of a file
+func (s *FSSource) Get(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, notExist("open", name)
+	}
+
+	return fs.ReadFile(s.FS, name)
+}
+
+// List returns the names of the files in a folder
+func (s *FSSource) List(folder string) ([]string, error) {
+	if !fs.ValidPath(folder) {
+		return nil, notExist("readdir", folder)
+	}
+
+	entries, err := fs.ReadDir(s.FS, folder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names, nil
+}
+
+// Stat returns the information of a file
+func (s *FSSource) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, notExist("stat", name)
+	}
+
+	return fs.Stat(s.FS, name)
+}
+
+// String returns the name of the source
+func (s *FSSource) String() string {
+	return s.Name
+}
+
+// LayeredSource looks for the templates in several sources. A file
+// is read from the first source that has it, so the first ones can
+// replace the templates of the last ones
+type LayeredSource []Source
+
+// Get returns the content of a file from the first source that has it
+func (l LayeredSource) Get(name string) ([]byte, error) {
+	for _, source := range l {
+		data, err := source.Get(name)
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return data, err
+		}
+	}
+
+	return nil, notExist("open", name)
+}
+
+// List returns the names of the files in a folder in all the
+// sources, without repeating them. Sources without the
+// folder are skipped
+func (l LayeredSource) List(folder string) ([]string, error) {
+	var names []string
+
+	seen := make(map[string]bool)
+
+	for _, source := range l {
+		list, err := source.List(folder)
+
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range list {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// Stat returns the information of a file from the first source that has it
+func (l LayeredSource) Stat(name string) (fs.FileInfo, error) {
+	for _, source := range l {
+		info, err := source.Stat(name)
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return info, err
+		}
+	}
+
+	return nil, notExist("stat", name)
+}
+
+// Make an error for a missing file. Names that are not valid,
+// like absolute paths, are missing too, as no template has them
+func notExist(op, name string) error {
+	return &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+}
+
+// Get the name of the source a file comes from. Layered sources
+// tell which of their sources has it. Sources without a String
+// method have no name
+func sourceName(s Source, name string) string {
+	if l, ok := s.(LayeredSource); ok {
+		for _, source := range l {
+			if _, err := source.Stat(name); err == nil {
+				return sourceName(source, name)
+			}
+		}
+
+		return ""
+	}
+
+	if named, ok := s.(interface{ String() string }); ok {
+		return named.String()
+	}
+
+	return ""
+}
@@ -0,0 +1,142 @@
+package gitgen
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+var (
+	first = &FSSource{"first", fstest.MapFS{
+		"ignores/Go.gitignore":   {Data: []byte("first")},
+		"ignores/Tool.gitignore": {Data: []byte("tool")},
+	}}
+
+	second = &FSSource{"second", fstest.MapFS{
+		"ignores/Go.gitignore":  {Data: []byte("second")},
+		"ignores/Zig.gitignore": {Data: []byte("zig")},
+		"licenses/acme.txt":     {Data: []byte("acme")},
+	}}
+)
+
+func TestFSSource(t *testing.T) {
+	data, err := Embedded().Get("ignores/Yeoman.gitignore")
+
+	if err != nil || len(data) != 52 {
+		t.Errorf("Get() = %d bytes, %v", len(data), err)
+	}
+
+	names, err := Embedded().List("licenses")
+
+	if err != nil || len(names) != 13 {
+		t.Errorf("List() = %v, %v", names, err)
+	}
+
+	// Invalid names do not exist
+	for _, name := range []string{"/etc/passwd", "../ignores/Go.gitignore", "ignores/"} {
+		if _, err := Embedded().Get(name); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Get(%q) error = %v, want fs.ErrNotExist", name, err)
+		}
+	}
+
+	if _, err := Embedded().List("nothing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("List() error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+
+	os.Mkdir(filepath.Join(dir, "ignores"), 0755)
+	os.Mkdir(filepath.Join(dir, "ignores", "sub"), 0755)
+	os.WriteFile(filepath.Join(dir, "ignores", "Go.gitignore"), []byte("bin/\n"), 0644)
+
+	s := DirSource(dir)
+
+	if s.String() != dir {
+		t.Errorf("String() = %v, want %v", s.String(), dir)
+	}
+
+	// Folders are not listed
+	if names, _ := s.List("ignores"); !reflect.DeepEqual(names, []string{"Go.gitignore"}) {
+		t.Errorf("List() = %v", names)
+	}
+
+	if info, err := s.Stat("ignores/Go.gitignore"); err != nil || info.Size() != 5 {
+		t.Errorf("Stat() = %v, %v", info, err)
+	}
+}
+
+func TestLayeredSource(t *testing.T) {
+	l := LayeredSource{first, second}
+
+	tests := []struct {
+		name, file, want string
+		wantErr          bool
+	}{
+		{"The first one wins", "ignores/Go.gitignore", "first", false},
+		{"Only in the first", "ignores/Tool.gitignore", "tool", false},
+		{"Only in the second", "licenses/acme.txt", "acme", false},
+		{"In none", "ignores/Rust.gitignore", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := l.Get(tt.file)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("Get() error = %v, want fs.ErrNotExist", err)
+			}
+
+			if got := string(data); got != tt.want {
+				t.Errorf("Get() = %v, want %v", got, tt.want)
+			}
+
+			_, err = l.Stat(tt.file)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Stat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	names, err := l.List("ignores")
+
+	if want := []string{"Go.gitignore", "Tool.gitignore", "Zig.gitignore"}; err != nil || !reflect.DeepEqual(names, want) {
+		t.Errorf("List() = %v, %v, want %v", names, err, want)
+	}
+
+	// Only the second one has licenses
+	if names, err := l.List("licenses"); err != nil || len(names) != 1 {
+		t.Errorf("List() = %v, %v", names, err)
+	}
+}
+
+func Test_sourceName(t *testing.T) {
+	l := LayeredSource{first, LayeredSource{second, Embedded()}}
+
+	tests := []struct {
+		file, want string
+	}{
+		{"ignores/Go.gitignore", "first"},
+		{"ignores/Zig.gitignore", "second"},
+		{"ignores/Yeoman.gitignore", EmbeddedSource},
+		{"ignores/Nothing.gitignore", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			if got := sourceName(l, tt.file); got != tt.want {
+				t.Errorf("sourceName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package gitgen
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// The rules of a .gitignore, which apply to
+// the paths inside of its folder
+type ignoreScope struct {
+	dir string
+	m   *Matcher
+}
+
+// The .gitignore files that apply to a path
+// while walking a tree, from the root down
+type ignoreStack []ignoreScope
+
+// Make the stack of the .gitignore files of a folder and its
+// parents up to the root of the repository, with the excludes
+// of the repository at the bottom
+func newIgnoreStack(dir string) ignoreStack {
+	var stack ignoreStack
+
+	dir, err := filepath.Abs(dir)
+
+	if err != nil {
+		return nil
+	}
+
+	root, err := RepoRoot(dir)
+
+	if err != nil {
+		return stack.push(dir)
+	}
+
+	if exclude, err := LocalExcludesFile(root); err == nil {
+		stack = stack.pushFile(root, exclude)
+	}
+
+	// From the root down to the folder
+	var dirs []string
+
+	for d := dir; d != root; d = filepath.Dir(d) {
+		dirs = append([]string{d}, dirs...)
+	}
+
+	stack = stack.push(root)
+
+	for _, d := range dirs {
+		stack = stack.push(d)
+	}
+
+	return stack
+}
+
+// Add the .gitignore of a folder, if it has one
+func (s ignoreStack) push(dir string) ignoreStack {
+	return s.pushFile(dir, filepath.Join(dir, ".gitignore"))
+}
+
+// Add the rules of a file for the paths inside of a folder
+func (s ignoreStack) pushFile(dir, name string) ignoreStack {
+	f, err := os.Open(name)
+
+	if err != nil {
+		return s
+	}
+
+	defer f.Close()
+
+	ignore, err := ParseIgnore(f)
+
+	if err != nil {
+		return s
+	}
+
+	m := NewMatcher()
+	m.Add(name, ignore)
+
+	return append(s, ignoreScope{dir, m})
+}
+
+// Remove the files of the folders that do not contain a path
+func (s ignoreStack) leave(name string) ignoreStack {
+	for len(s) != 0 && !inDir(name, s[len(s)-1].dir) {
+		s = s[:len(s)-1]
+	}
+
+	return s
+}
+
+// Tell if git ignores a path. The closest .gitignore decides
+func (s ignoreStack) ignored(name string, isDir bool) bool {
+	for i := len(s) - 1; i >= 0; i-- {
+		rel, err := filepath.Rel(s[i].dir, name)
+
+		if err != nil {
+			continue
+		}
+
+		if match, ok := s[i].m.Match(filepath.ToSlash(rel), isDir); ok {
+			return match.Ignored()
+		}
+	}
+
+	return false
+}
+
+// Tell if a path is inside of a folder
+func inDir(name, dir string) bool {
+	rel, err := filepath.Rel(dir, name)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Get the files of some paths, sorted and without repeating them.
+// The paths work like the packages of the go command: a folder has
+// its files, and dir/... has the files of all its subfolders too.
+// Hidden and dependency folders are skipped, like the files and
+// folders ignored by git. A file given by name is always included
+func sourceFiles(paths []string) ([]string, error) {
+	seen := make(map[string]bool)
+
+	var files []string
+
+	add := func(file string) {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+
+	for _, p := range paths {
+		recursive := p == "..." || strings.HasSuffix(p, "/...")
+
+		if recursive {
+			p = filepath.Clean(strings.TrimSuffix(p, "..."))
+		}
+
+		info, err := os.Stat(p)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			add(p)
+			continue
+		}
+
+		if err := walkSourceFiles(p, recursive, add); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// Walk a folder and add the files that are not ignored
+func walkSourceFiles(dir string, recursive bool, add func(string)) error {
+	abs, err := filepath.Abs(dir)
+
+	if err != nil {
+		return err
+	}
+
+	ignores := newIgnoreStack(abs)
+
+	return filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if name == dir {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, name)
+
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(abs, rel)
+
+		ignores = ignores.leave(path)
+
+		if ignores.ignored(path, d.IsDir()) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.IsDir() {
+			add(name)
+			return nil
+		}
+
+		// Skip hidden folders and dependencies like DetectIgnores
+		if !recursive || strings.HasPrefix(d.Name(), ".") || skipDirs[d.Name()] {
+			return fs.SkipDir
+		}
+
+		ignores = ignores.push(path)
+
+		return nil
+	})
+}
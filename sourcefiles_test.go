@@ -0,0 +1,106 @@
+package gitgen
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Make a repository with ignored, hidden and dependency files
+func makeSourceTree(t *testing.T) string {
+	root := t.TempDir()
+
+	files := map[string]string{
+		".git/info/exclude":   "*.log\n",
+		".gitignore":          "gen/\n*.tmp\n",
+		"main.go":             "package main\n",
+		"a.tmp":               "",
+		"debug.log":           "",
+		"pkg/.gitignore":      "!keep.tmp\nold.go\n",
+		"pkg/lib.go":          "package pkg\n",
+		"pkg/old.go":          "package pkg\n",
+		"pkg/keep.tmp":        "",
+		"pkg/sub/deep.go":     "package sub\n",
+		"gen/gen.go":          "package gen\n",
+		"node_modules/x.js":   "",
+		".hidden/h.go":        "package hidden\n",
+		"pkg/vendor/v/v.go":   "package v\n",
+		"pkg/sub/.cache/c.go": "package c\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func Test_sourceFiles(t *testing.T) {
+	root := makeSourceTree(t)
+
+	// The paths are relative to the repository
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+
+	os.Chdir(root)
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{
+			"Everything",
+			[]string{"./..."},
+			[]string{".gitignore", "main.go", "pkg/.gitignore", "pkg/keep.tmp", "pkg/lib.go", "pkg/sub/deep.go"},
+		},
+		{
+			"One folder",
+			[]string{"pkg"},
+			[]string{"pkg/.gitignore", "pkg/keep.tmp", "pkg/lib.go"},
+		},
+		{
+			"Subfolders",
+			[]string{"pkg/..."},
+			[]string{"pkg/.gitignore", "pkg/keep.tmp", "pkg/lib.go", "pkg/sub/deep.go"},
+		},
+		{
+			"Files by name",
+			[]string{"gen/gen.go", "pkg", "pkg/lib.go"},
+			[]string{"gen/gen.go", "pkg/.gitignore", "pkg/keep.tmp", "pkg/lib.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sourceFiles(tt.paths)
+
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			want := make([]string, len(tt.want))
+
+			for i, name := range tt.want {
+				want[i] = filepath.FromSlash(name)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("sourceFiles() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	if _, err := sourceFiles([]string{"missing/..."}); !os.IsNotExist(err) {
+		t.Errorf("Expected a missing folder to fail, got %v", err)
+	}
+}
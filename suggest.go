@@ -0,0 +1,164 @@
+package gitgen
+
+import (
+	"sort"
+	"strings"
+)
+
+// The number of suggestions included in a NotFoundError
+const maxSuggestions = 3
+
+// SuggestIgnores returns the names of the gitignore templates
+// that look like the key, the best match first. It is useful
+// for "did you mean" messages. At most n names are returned,
+// or all of them if n is 0 or less
+func SuggestIgnores(key string, n int) []string {
+	return defaultGenerator().SuggestIgnores(key, n)
+}
+
+// SuggestIgnores returns the gitignore templates
+// of the source that look like the key
+func (g *Generator) SuggestIgnores(key string, n int) []string {
+	return suggest(key, g.ignoreNames(), n)
+}
+
+// SuggestLicenses is like SuggestIgnores but for licenses
+func SuggestLicenses(key string, n int) []string {
+	return defaultGenerator().SuggestLicenses(key, n)
+}
+
+// SuggestLicenses returns the licenses of the
+// source that look like the key
+func (g *Generator) SuggestLicenses(key string, n int) []string {
+	return suggest(key, g.licenseNames(), n)
+}
+
+// A possible match and how far it is from the key. Lower is better
+type suggestion struct {
+	name  string
+	score int
+}
+
+// Rank the names by how close they are to the key. Names that
+// start with the key come first, then names containing it and
+// then names within a small edit distance
+func suggest(key string, names []string, n int) []string {
+	norm := normalizeName(key)
+
+	// An empty key would match everything
+	if norm == "" {
+		return nil
+	}
+
+	// Allow one typo for every three characters
+	threshold := len(norm) / 3
+
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	var found []suggestion
+
+	for _, name := range names {
+		candidate := normalizeName(name)
+
+		switch {
+		case strings.HasPrefix(candidate, norm):
+			found = append(found, suggestion{name, 0})
+
+		case strings.Contains(candidate, norm):
+			found = append(found, suggestion{name, 1})
+
+		default:
+			if d := editDistance(norm, candidate); d <= threshold {
+				found = append(found, suggestion{name, 1 + d})
+			}
+		}
+	}
+
+	// Best score first, then alphabetically
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].score != found[j].score {
+			return found[i].score < found[j].score
+		}
+
+		return strings.ToLower(found[i].name) < strings.ToLower(found[j].name)
+	})
+
+	if n > 0 && len(found) > n {
+		found = found[:n]
+	}
+
+	var ranked []string
+
+	for _, s := range found {
+		ranked = append(ranked, s.name)
+	}
+
+	return ranked
+}
+
+// Make a name lower case and remove the punctuation so
+// apache2 and apache-2.0 can be compared
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '.', ' ':
+			return -1
+		}
+
+		return r
+	}, strings.ToLower(name))
+}
+
+// Compute the optimal string alignment distance between two
+// strings, which is the Levenshtein distance but counting the
+// swap of two adjacent characters as a single edit
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	// d[i][j] is the distance between s[:i] and t[:j]
+	d := make([][]int, len(s)+1)
+
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = minInt(
+				d[i-1][j]+1,      // Deletion
+				d[i][j-1]+1,      // Insertion
+				d[i-1][j-1]+cost, // Substitution
+			)
+
+			// Transposition
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(s)][len(t)]
+}
+
+func minInt(first int, rest ...int) int {
+	for _, v := range rest {
+		if v < first {
+			first = v
+		}
+	}
+
+	return first
+}
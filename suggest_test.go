@@ -0,0 +1,85 @@
+package gitgen
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSuggestIgnores(t *testing.T) {
+	tests := []struct {
+		name, key string
+		n         int
+		want      []string
+	}{
+		{"Transposed letters", "Pyhton", 3, []string{"Python"}},
+		{"Missing letter", "Jva", 3, []string{"Java"}},
+		{"Prefix before contains", "go", 0, []string{"Go", "Godot", "IGORPro"}},
+		{"Limit the results", "go", 1, []string{"Go"}},
+		{"Nothing close", "WakandaForever", 3, nil},
+		{"Empty key", "", 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuggestIgnores(tt.key, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SuggestIgnores() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestLicenses(t *testing.T) {
+	tests := []struct {
+		name, key string
+		want      []string
+	}{
+		{"Punctuation is ignored", "apache20", []string{"apache-2.0"}},
+		{"Prefix", "bsd", []string{"bsd-2-clause", "bsd-3-clause"}},
+		{"Typo in the version", "gpl-4.0", []string{"gpl-2.0", "gpl-3.0"}},
+		{"Nothing close", "lol", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuggestLicenses(tt.key, 0); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SuggestLicenses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_editDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"pyhton", "python", 1}, // A swap is a single edit
+		{"go", "go", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := editDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("editDistance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotFoundError_Suggestions(t *testing.T) {
+	var nf *NotFoundError
+
+	_, err := IgnoreText("Pyhton")
+
+	if !errors.As(err, &nf) {
+		t.Fatalf("Expected a *NotFoundError, got %v", err)
+	}
+
+	if want := []string{"Python"}; !reflect.DeepEqual(nf.Suggestions, want) {
+		t.Errorf("Suggestions = %v, want %v", nf.Suggestions, want)
+	}
+}